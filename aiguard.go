@@ -2,6 +2,7 @@ package aidr
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -39,9 +40,21 @@ func NewAIGuardService(opts ...option.RequestOption) (r AIGuardService) {
 
 // Will retrieve the result, or will return 202 if the original request is still in
 // progress
+//
+// A 202 response is returned as an [*AcceptedError].
 func (r *AIGuardService) GetAsyncRequest(ctx context.Context, requestID string, opts ...option.RequestOption) (res *AIGuardGetAsyncRequestResponse, err error) {
+	return GetAsyncRequestAs[any](ctx, r, requestID, opts...)
+}
+
+// GetAsyncRequestAs retrieves the result of an asynchronous request like
+// [AIGuardService.GetAsyncRequest], decoding the result as T. For the result of a
+// deferred [AIGuardService.GuardChatCompletions] call, use
+// [AIGuardGuardChatCompletionsResponseResult].
+//
+// A 202 response is returned as an [*AcceptedError].
+func GetAsyncRequestAs[T any](ctx context.Context, r *AIGuardService, requestID string, opts ...option.RequestOption) (res *AIGuardGetAsyncRequestResponseOf[T], err error) {
 	opts = slices.Concat(r.Options, opts)
-	opts = append(opts, option.WithServiceName(r.ServiceName))
+	opts = append(opts, option.WithServiceName(r.ServiceName), requestconfig.WithAcceptedError())
 	if requestID == "" {
 		err = errors.New("missing required requestId parameter")
 		return res, err
@@ -53,16 +66,22 @@ func (r *AIGuardService) GetAsyncRequest(ctx context.Context, requestID string,
 
 // Analyze and redact content to avoid manipulation of the model, addition of
 // malicious content, and other undesirable data transfers.
+//
+// If the API defers processing, an [*AcceptedError] is returned and the result
+// must be retrieved with [AIGuardService.GetAsyncRequest].
 func (r *AIGuardService) GuardChatCompletions(ctx context.Context, body AIGuardGuardChatCompletionsParams, opts ...option.RequestOption) (res *AIGuardGuardChatCompletionsResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	opts = append(opts, option.WithServiceName(r.ServiceName))
+	opts = append(opts, option.WithServiceName(r.ServiceName), requestconfig.WithAcceptedError())
 	path := "v1/guard_chat_completions"
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
 	return res, err
 }
 
 // Pangea standard response schema
-type AIGuardGetAsyncRequestResponse struct {
+type AIGuardGetAsyncRequestResponse = AIGuardGetAsyncRequestResponseOf[any]
+
+// Pangea standard response schema, with the result decoded as T.
+type AIGuardGetAsyncRequestResponseOf[T any] struct {
 	// A unique identifier assigned to each request made to the API. It is used to
 	// track and identify a specific request and its associated data. The `request_id`
 	// can be helpful for troubleshooting, auditing, and tracing the flow of requests
@@ -100,8 +119,8 @@ type AIGuardGetAsyncRequestResponse struct {
 	// ```
 	// "status":"success"
 	// ```
-	Status string `json:"status,required"`
-	Result any    `json:"result"`
+	Status AIGuardGetAsyncRequestResponseStatus `json:"status,required"`
+	Result T                                    `json:"result"`
 	// Provides a concise and brief overview of the purpose or primary objective of the
 	// API endpoint. It serves as a high-level summary or description of the
 	// functionality or feature offered by the endpoint.
@@ -120,12 +139,43 @@ type AIGuardGetAsyncRequestResponse struct {
 }
 
 // Returns the unmodified JSON received from the API
-func (r AIGuardGetAsyncRequestResponse) RawJSON() string { return r.JSON.raw }
+func (r AIGuardGetAsyncRequestResponseOf[T]) RawJSON() string { return r.JSON.raw }
 
-func (r *AIGuardGetAsyncRequestResponse) UnmarshalJSON(data []byte) error {
+func (r *AIGuardGetAsyncRequestResponseOf[T]) UnmarshalJSON(data []byte) error {
 	return apijson.UnmarshalRoot(data, r)
 }
 
+// AsGuardChatCompletionsResult decodes the result of a deferred
+// [AIGuardService.GuardChatCompletions] call.
+func (r AIGuardGetAsyncRequestResponseOf[T]) AsGuardChatCompletionsResult() (v AIGuardGuardChatCompletionsResponseResult, err error) {
+	err = apijson.UnmarshalRoot(json.RawMessage(r.JSON.Result.Raw()), &v)
+	return v, err
+}
+
+// It represents the status or outcome of the API request. Any status other than
+// "Success" or "Accepted" is a failure.
+type AIGuardGetAsyncRequestResponseStatus string
+
+const (
+	AIGuardGetAsyncRequestResponseStatusSuccess  AIGuardGetAsyncRequestResponseStatus = "Success"
+	AIGuardGetAsyncRequestResponseStatusAccepted AIGuardGetAsyncRequestResponseStatus = "Accepted"
+)
+
+// IsPending reports whether the request is still being processed.
+func (s AIGuardGetAsyncRequestResponseStatus) IsPending() bool {
+	return s == AIGuardGetAsyncRequestResponseStatusAccepted
+}
+
+// IsSuccess reports whether the request completed successfully.
+func (s AIGuardGetAsyncRequestResponseStatus) IsSuccess() bool {
+	return s == AIGuardGetAsyncRequestResponseStatusSuccess
+}
+
+// IsFailure reports whether the request completed unsuccessfully.
+func (s AIGuardGetAsyncRequestResponseStatus) IsFailure() bool {
+	return !s.IsPending() && !s.IsSuccess()
+}
+
 type AIGuardGuardChatCompletionsResponse struct {
 	// A unique identifier assigned to each request made to the API. It is used to
 	// track and identify a specific request and its associated data. The `request_id`
@@ -195,15 +245,15 @@ func (r *AIGuardGuardChatCompletionsResponse) UnmarshalJSON(data []byte) error {
 type AIGuardGuardChatCompletionsResponseResult struct {
 	// Result of the policy analyzing and input prompt.
 	Detectors AIGuardGuardChatCompletionsResponseResultDetectors `json:"detectors,required"`
-	// Result of the recipe evaluating configured rules
-	AccessRules any `json:"access_rules"`
+	// Result of the recipe evaluating configured rules, keyed by rule.
+	AccessRules map[string]AccessRuleResult `json:"access_rules"`
 	// Whether or not the prompt triggered a block detection.
 	Blocked bool `json:"blocked"`
 	// If an FPE redaction method returned results, this will be the context passed to
 	// unredact.
 	FpeContext string `json:"fpe_context" format:"base64"`
 	// Updated structured prompt.
-	GuardOutput any `json:"guard_output"`
+	GuardOutput AIGuardGuardChatCompletionsResponseResultGuardOutput `json:"guard_output"`
 	// The Policy that was used.
 	Policy string `json:"policy"`
 	// Whether or not the original input was transformed.
@@ -229,6 +279,84 @@ func (r *AIGuardGuardChatCompletionsResponseResult) UnmarshalJSON(data []byte) e
 	return apijson.UnmarshalRoot(data, r)
 }
 
+// Updated structured prompt.
+type AIGuardGuardChatCompletionsResponseResultGuardOutput struct {
+	// Prompt content and role array.
+	Messages []ChatMessage `json:"messages"`
+	// Tools available to the model.
+	Tools []ChatTool `json:"tools"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Messages    respjson.Field
+		Tools       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r AIGuardGuardChatCompletionsResponseResultGuardOutput) RawJSON() string {
+	return r.JSON.raw
+}
+
+func (r *AIGuardGuardChatCompletionsResponseResultGuardOutput) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this AIGuardGuardChatCompletionsResponseResultGuardOutput to a
+// AIGuardGuardChatCompletionsParamsGuardInput.
+//
+// Warning: the fields of the param type will not be present. ToParam should only
+// be used at the last possible moment before sending a request. Test for this
+// with AIGuardGuardChatCompletionsParamsGuardInput.Overrides()
+func (r AIGuardGuardChatCompletionsResponseResultGuardOutput) ToParam() AIGuardGuardChatCompletionsParamsGuardInput {
+	return param.Override[AIGuardGuardChatCompletionsParamsGuardInput](json.RawMessage(r.RawJSON()))
+}
+
+// Details about the evaluation of a single rule, including whether it matched, the
+// action to take, the rule name, and optional debugging information.
+type AccessRuleResult struct {
+	// The action resulting from the rule evaluation. One of 'allowed', 'blocked', or
+	// 'reported'.
+	//
+	// Any of "allowed", "blocked", "reported".
+	Action AccessRuleResultAction `json:"action,required"`
+	// Whether this rule's logic evaluated to true for the input.
+	Matched bool `json:"matched,required"`
+	// A human-readable name for the rule.
+	Name string `json:"name,required"`
+	// The input attribute values that were available during rule evaluation.
+	Attributes map[string]any `json:"attributes"`
+	// The JSON logic expression evaluated for this rule.
+	Logic map[string]any `json:"logic"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Action      respjson.Field
+		Matched     respjson.Field
+		Name        respjson.Field
+		Attributes  respjson.Field
+		Logic       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r AccessRuleResult) RawJSON() string { return r.JSON.raw }
+
+func (r *AccessRuleResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The action resulting from the rule evaluation.
+type AccessRuleResultAction string
+
+const (
+	AccessRuleResultActionAllowed  AccessRuleResultAction = "allowed"
+	AccessRuleResultActionBlocked  AccessRuleResultAction = "blocked"
+	AccessRuleResultActionReported AccessRuleResultAction = "reported"
+)
+
 // Result of the policy analyzing and input prompt.
 type AIGuardGuardChatCompletionsResponseResultDetectors struct {
 	Code                     AIGuardGuardChatCompletionsResponseResultDetectorsCode                     `json:"code"`
@@ -830,7 +958,7 @@ type AIGuardGuardChatCompletionsParams struct {
 	// 'messages' contains Prompt content and role array in JSON format. The `content`
 	// is the multimodel text or image input that will be analyzed. Additional
 	// properties such as 'tools' may be provided for analysis.
-	GuardInput any `json:"guard_input,omitzero,required"`
+	GuardInput AIGuardGuardChatCompletionsParamsGuardInput `json:"guard_input,omitzero,required"`
 	// Id of source application/agent
 	AppID param.Opt[string] `json:"app_id,omitzero"`
 	// (AIDR) collector instance id.
@@ -867,6 +995,30 @@ func (r *AIGuardGuardChatCompletionsParams) UnmarshalJSON(data []byte) error {
 	return apijson.UnmarshalRoot(data, r)
 }
 
+// 'messages' contains Prompt content and role array in JSON format. The `content`
+// is the multimodel text or image input that will be analyzed. Additional
+// properties such as 'tools' may be provided for analysis.
+//
+// To send an arbitrary JSON object instead, use [param.Override]:
+//
+//	param.Override[AIGuardGuardChatCompletionsParamsGuardInput](map[string]any{...})
+type AIGuardGuardChatCompletionsParamsGuardInput struct {
+	// Prompt content and role array.
+	Messages []ChatMessageParam `json:"messages,omitzero"`
+	// Tools available to the model.
+	Tools []ChatToolParam `json:"tools,omitzero"`
+	paramObj
+}
+
+func (r AIGuardGuardChatCompletionsParamsGuardInput) MarshalJSON() (data []byte, err error) {
+	type shadow AIGuardGuardChatCompletionsParamsGuardInput
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *AIGuardGuardChatCompletionsParamsGuardInput) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
 // (AIDR) Event Type.
 type AIGuardGuardChatCompletionsParamsEventType string
 
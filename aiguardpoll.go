@@ -0,0 +1,132 @@
+package aidr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/requestconfig"
+	"github.com/crowdstrike/aidr-go/option"
+)
+
+// PollOptions configures how [AIGuardService.GuardChatCompletionsAndWait] polls
+// for the result of a request that the API deferred.
+type PollOptions struct {
+	// Interval is the delay before the first poll. Later polls back off
+	// exponentially up to MaxInterval. Defaults to 1 second.
+	Interval time.Duration
+	// MaxInterval caps the delay between two polls. Defaults to 10 seconds.
+	MaxInterval time.Duration
+	// MaxWait bounds the total time spent polling. The last poll is made when
+	// MaxWait elapses if the next one would be later. Zero means polling only stops
+	// when the context is done.
+	MaxWait time.Duration
+	// OnPoll, if set, is called every time the result is still pending.
+	OnPoll func(PollProgress)
+}
+
+// PollProgress describes a pending request while it is being polled.
+type PollProgress struct {
+	// The ID of the pending request.
+	RequestID string
+	// The number of polls made so far. Zero for the initial submission.
+	Attempt int
+	// The time elapsed since the request was submitted.
+	Elapsed time.Duration
+	// The delay before the next poll.
+	NextPoll time.Duration
+	// The most recent 202 response.
+	Accepted *AcceptedError
+}
+
+// GuardChatCompletionsAndWait calls [AIGuardService.GuardChatCompletions] and, if
+// the API defers processing, polls the result with the request ID until it is
+// final. Waiting honors Retry-After headers and stops when ctx is done or
+// [PollOptions.MaxWait] elapses, in which case the returned error wraps the last
+// [*AcceptedError].
+func (r *AIGuardService) GuardChatCompletionsAndWait(ctx context.Context, body AIGuardGuardChatCompletionsParams, poll PollOptions, opts ...option.RequestOption) (res *AIGuardGuardChatCompletionsResponse, err error) {
+	start := time.Now()
+	res, err = r.GuardChatCompletions(ctx, body, opts...)
+
+	var accepted *AcceptedError
+	if !errors.As(err, &accepted) {
+		return res, err
+	}
+
+	if poll.Interval <= 0 {
+		poll.Interval = time.Second
+	}
+	if poll.MaxInterval <= 0 {
+		poll.MaxInterval = 10 * time.Second
+	}
+
+	requestID := accepted.RequestID
+	if requestID == "" {
+		return nil, fmt.Errorf("aidr: cannot poll a deferred request without a request ID: %w", err)
+	}
+	for attempt := 0; ; attempt++ {
+		delay := requestconfig.BackoffDelay(accepted.Response, attempt, poll.Interval, poll.MaxInterval)
+		elapsed := time.Since(start)
+		if poll.MaxWait > 0 {
+			remaining := poll.MaxWait - elapsed
+			if remaining <= 0 {
+				return nil, fmt.Errorf("aidr: gave up waiting for request %s after %s: %w", requestID, elapsed.Round(time.Millisecond), accepted)
+			}
+			// Poll a last time when MaxWait elapses rather than giving up early.
+			delay = min(delay, remaining)
+		}
+		if poll.OnPoll != nil {
+			poll.OnPoll(PollProgress{
+				RequestID: requestID,
+				Attempt:   attempt,
+				Elapsed:   elapsed,
+				NextPoll:  delay,
+				Accepted:  accepted,
+			})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		got, err := GetAsyncRequestAs[AIGuardGuardChatCompletionsResponseResult](ctx, r, requestID, opts...)
+		if errors.As(err, &accepted) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if got.Status.IsFailure() {
+			return nil, fmt.Errorf("aidr: request %s failed with status %q: %s", requestID, got.Status, got.Summary)
+		}
+		return guardChatCompletionsResponseOf(got), nil
+	}
+}
+
+// guardChatCompletionsResponseOf converts the result of a polled request to the
+// response GuardChatCompletions would have returned, keeping the raw JSON and
+// field metadata.
+func guardChatCompletionsResponseOf(got *AIGuardGetAsyncRequestResponseOf[AIGuardGuardChatCompletionsResponseResult]) *AIGuardGuardChatCompletionsResponse {
+	res := &AIGuardGuardChatCompletionsResponse{
+		RequestID:    got.RequestID,
+		RequestTime:  got.RequestTime,
+		ResponseTime: got.ResponseTime,
+		Result:       got.Result,
+		Status:       AIGuardGuardChatCompletionsResponseStatus(got.Status),
+		Summary:      got.Summary,
+	}
+	res.JSON.RequestID = got.JSON.RequestID
+	res.JSON.RequestTime = got.JSON.RequestTime
+	res.JSON.ResponseTime = got.JSON.ResponseTime
+	res.JSON.Result = got.JSON.Result
+	res.JSON.Status = got.JSON.Status
+	res.JSON.Summary = got.JSON.Summary
+	res.JSON.ExtraFields = got.JSON.ExtraFields
+	res.JSON.raw = got.JSON.raw
+	return res
+}
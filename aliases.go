@@ -6,9 +6,218 @@ import (
 )
 
 // aliased to make [param.APIUnion] private when embedding
-type paramUnion = param.APIUnion //nolint:unused
+type paramUnion = param.APIUnion
 
 // aliased to make [param.APIObject] private when embedding
 type paramObj = param.APIObject
 
 type Error = apierror.Error
+
+type AcceptedError = apierror.AcceptedError
+
+type ErrorResult = apierror.ErrorResult
+
+type ValidationError = apierror.ValidationError
+
+type ValidationErrorCode = apierror.ValidationErrorCode
+
+// Equals apierror.ValidationErrorCodeFieldRequired
+const ValidationErrorCodeFieldRequired = apierror.ValidationErrorCodeFieldRequired
+
+// Equals apierror.ValidationErrorCodeInvalidString
+const ValidationErrorCodeInvalidString = apierror.ValidationErrorCodeInvalidString
+
+// Equals apierror.ValidationErrorCodeInvalidNumber
+const ValidationErrorCodeInvalidNumber = apierror.ValidationErrorCodeInvalidNumber
+
+// Equals apierror.ValidationErrorCodeInvalidInteger
+const ValidationErrorCodeInvalidInteger = apierror.ValidationErrorCodeInvalidInteger
+
+// Equals apierror.ValidationErrorCodeInvalidObject
+const ValidationErrorCodeInvalidObject = apierror.ValidationErrorCodeInvalidObject
+
+// Equals apierror.ValidationErrorCodeInvalidArray
+const ValidationErrorCodeInvalidArray = apierror.ValidationErrorCodeInvalidArray
+
+// Equals apierror.ValidationErrorCodeInvalidNull
+const ValidationErrorCodeInvalidNull = apierror.ValidationErrorCodeInvalidNull
+
+// Equals apierror.ValidationErrorCodeInvalidBool
+const ValidationErrorCodeInvalidBool = apierror.ValidationErrorCodeInvalidBool
+
+// Equals apierror.ValidationErrorCodeBadFormat
+const ValidationErrorCodeBadFormat = apierror.ValidationErrorCodeBadFormat
+
+// Equals apierror.ValidationErrorCodeBadFormatPangeaDuration
+const ValidationErrorCodeBadFormatPangeaDuration = apierror.ValidationErrorCodeBadFormatPangeaDuration
+
+// Equals apierror.ValidationErrorCodeBadFormatDateTime
+const ValidationErrorCodeBadFormatDateTime = apierror.ValidationErrorCodeBadFormatDateTime
+
+// Equals apierror.ValidationErrorCodeBadFormatTime
+const ValidationErrorCodeBadFormatTime = apierror.ValidationErrorCodeBadFormatTime
+
+// Equals apierror.ValidationErrorCodeBadFormatDate
+const ValidationErrorCodeBadFormatDate = apierror.ValidationErrorCodeBadFormatDate
+
+// Equals apierror.ValidationErrorCodeBadFormatEmail
+const ValidationErrorCodeBadFormatEmail = apierror.ValidationErrorCodeBadFormatEmail
+
+// Equals apierror.ValidationErrorCodeBadFormatHostname
+const ValidationErrorCodeBadFormatHostname = apierror.ValidationErrorCodeBadFormatHostname
+
+// Equals apierror.ValidationErrorCodeBadFormatIPv4
+const ValidationErrorCodeBadFormatIPv4 = apierror.ValidationErrorCodeBadFormatIPv4
+
+// Equals apierror.ValidationErrorCodeBadFormatIPv6
+const ValidationErrorCodeBadFormatIPv6 = apierror.ValidationErrorCodeBadFormatIPv6
+
+// Equals apierror.ValidationErrorCodeBadFormatIPAddress
+const ValidationErrorCodeBadFormatIPAddress = apierror.ValidationErrorCodeBadFormatIPAddress
+
+// Equals apierror.ValidationErrorCodeBadFormatUUID
+const ValidationErrorCodeBadFormatUUID = apierror.ValidationErrorCodeBadFormatUUID
+
+// Equals apierror.ValidationErrorCodeBadFormatURI
+const ValidationErrorCodeBadFormatURI = apierror.ValidationErrorCodeBadFormatURI
+
+// Equals apierror.ValidationErrorCodeBadFormatURIReference
+const ValidationErrorCodeBadFormatURIReference = apierror.ValidationErrorCodeBadFormatURIReference
+
+// Equals apierror.ValidationErrorCodeBadFormatIRI
+const ValidationErrorCodeBadFormatIRI = apierror.ValidationErrorCodeBadFormatIRI
+
+// Equals apierror.ValidationErrorCodeBadFormatIRIReference
+const ValidationErrorCodeBadFormatIRIReference = apierror.ValidationErrorCodeBadFormatIRIReference
+
+// Equals apierror.ValidationErrorCodeBadFormatJSONPointer
+const ValidationErrorCodeBadFormatJSONPointer = apierror.ValidationErrorCodeBadFormatJSONPointer
+
+// Equals apierror.ValidationErrorCodeBadFormatRelativeJSONPointer
+const ValidationErrorCodeBadFormatRelativeJSONPointer = apierror.ValidationErrorCodeBadFormatRelativeJSONPointer
+
+// Equals apierror.ValidationErrorCodeBadFormatRegex
+const ValidationErrorCodeBadFormatRegex = apierror.ValidationErrorCodeBadFormatRegex
+
+// Equals apierror.ValidationErrorCodeBadFormatJSONPath
+const ValidationErrorCodeBadFormatJSONPath = apierror.ValidationErrorCodeBadFormatJSONPath
+
+// Equals apierror.ValidationErrorCodeBadFormatBase64
+const ValidationErrorCodeBadFormatBase64 = apierror.ValidationErrorCodeBadFormatBase64
+
+// Equals apierror.ValidationErrorCodeDoesNotMatchPattern
+const ValidationErrorCodeDoesNotMatchPattern = apierror.ValidationErrorCodeDoesNotMatchPattern
+
+// Equals apierror.ValidationErrorCodeDoesNotMatchPatternProperties
+const ValidationErrorCodeDoesNotMatchPatternProperties = apierror.ValidationErrorCodeDoesNotMatchPatternProperties
+
+// Equals apierror.ValidationErrorCodeNotEnumMember
+const ValidationErrorCodeNotEnumMember = apierror.ValidationErrorCodeNotEnumMember
+
+// Equals apierror.ValidationErrorCodeAboveMaxLength
+const ValidationErrorCodeAboveMaxLength = apierror.ValidationErrorCodeAboveMaxLength
+
+// Equals apierror.ValidationErrorCodeBelowMinLength
+const ValidationErrorCodeBelowMinLength = apierror.ValidationErrorCodeBelowMinLength
+
+// Equals apierror.ValidationErrorCodeAboveMaxItems
+const ValidationErrorCodeAboveMaxItems = apierror.ValidationErrorCodeAboveMaxItems
+
+// Equals apierror.ValidationErrorCodeBelowMinItems
+const ValidationErrorCodeBelowMinItems = apierror.ValidationErrorCodeBelowMinItems
+
+// Equals apierror.ValidationErrorCodeNotMultipleOf
+const ValidationErrorCodeNotMultipleOf = apierror.ValidationErrorCodeNotMultipleOf
+
+// Equals apierror.ValidationErrorCodeNotWithinRange
+const ValidationErrorCodeNotWithinRange = apierror.ValidationErrorCodeNotWithinRange
+
+// Equals apierror.ValidationErrorCodeUnexpectedProperty
+const ValidationErrorCodeUnexpectedProperty = apierror.ValidationErrorCodeUnexpectedProperty
+
+// Equals apierror.ValidationErrorCodeInvalidPropertyName
+const ValidationErrorCodeInvalidPropertyName = apierror.ValidationErrorCodeInvalidPropertyName
+
+// Equals apierror.ValidationErrorCodeAboveMaxProperties
+const ValidationErrorCodeAboveMaxProperties = apierror.ValidationErrorCodeAboveMaxProperties
+
+// Equals apierror.ValidationErrorCodeBelowMinProperties
+const ValidationErrorCodeBelowMinProperties = apierror.ValidationErrorCodeBelowMinProperties
+
+// Equals apierror.ValidationErrorCodeNotContains
+const ValidationErrorCodeNotContains = apierror.ValidationErrorCodeNotContains
+
+// Equals apierror.ValidationErrorCodeContainsTooMany
+const ValidationErrorCodeContainsTooMany = apierror.ValidationErrorCodeContainsTooMany
+
+// Equals apierror.ValidationErrorCodeContainsTooFew
+const ValidationErrorCodeContainsTooFew = apierror.ValidationErrorCodeContainsTooFew
+
+// Equals apierror.ValidationErrorCodeItemNotUnique
+const ValidationErrorCodeItemNotUnique = apierror.ValidationErrorCodeItemNotUnique
+
+// Equals apierror.ValidationErrorCodeUnexpectedAdditionalItem
+const ValidationErrorCodeUnexpectedAdditionalItem = apierror.ValidationErrorCodeUnexpectedAdditionalItem
+
+// Equals apierror.ValidationErrorCodeInvalidConst
+const ValidationErrorCodeInvalidConst = apierror.ValidationErrorCodeInvalidConst
+
+// Equals apierror.ValidationErrorCodeIsDependentOn
+const ValidationErrorCodeIsDependentOn = apierror.ValidationErrorCodeIsDependentOn
+
+// Equals apierror.ValidationErrorCodeIsTooBig
+const ValidationErrorCodeIsTooBig = apierror.ValidationErrorCodeIsTooBig
+
+// Equals apierror.ValidationErrorCodeIsTooSmall
+const ValidationErrorCodeIsTooSmall = apierror.ValidationErrorCodeIsTooSmall
+
+// Equals apierror.ValidationErrorCodeShouldNotBeValid
+const ValidationErrorCodeShouldNotBeValid = apierror.ValidationErrorCodeShouldNotBeValid
+
+// Equals apierror.ValidationErrorCodeNoUnevaluatedItems
+const ValidationErrorCodeNoUnevaluatedItems = apierror.ValidationErrorCodeNoUnevaluatedItems
+
+// Equals apierror.ValidationErrorCodeNoUnevaluatedProperties
+const ValidationErrorCodeNoUnevaluatedProperties = apierror.ValidationErrorCodeNoUnevaluatedProperties
+
+// Equals apierror.ValidationErrorCodeDoesNotExist
+const ValidationErrorCodeDoesNotExist = apierror.ValidationErrorCodeDoesNotExist
+
+// Equals apierror.ValidationErrorCodeIsReadOnly
+const ValidationErrorCodeIsReadOnly = apierror.ValidationErrorCodeIsReadOnly
+
+// Equals apierror.ValidationErrorCodeCannotAddToDefault
+const ValidationErrorCodeCannotAddToDefault = apierror.ValidationErrorCodeCannotAddToDefault
+
+// Equals apierror.ValidationErrorCodeMustProvideOne
+const ValidationErrorCodeMustProvideOne = apierror.ValidationErrorCodeMustProvideOne
+
+// Equals apierror.ValidationErrorCodeMutuallyExclusive
+const ValidationErrorCodeMutuallyExclusive = apierror.ValidationErrorCodeMutuallyExclusive
+
+// Equals apierror.ValidationErrorCodeBadState
+const ValidationErrorCodeBadState = apierror.ValidationErrorCodeBadState
+
+// Equals apierror.ValidationErrorCodeInaccessibleURI
+const ValidationErrorCodeInaccessibleURI = apierror.ValidationErrorCodeInaccessibleURI
+
+// Equals apierror.ValidationErrorCodeProviderDisabled
+const ValidationErrorCodeProviderDisabled = apierror.ValidationErrorCodeProviderDisabled
+
+// Equals apierror.ValidationErrorCodeConfigProjectMismatch
+const ValidationErrorCodeConfigProjectMismatch = apierror.ValidationErrorCodeConfigProjectMismatch
+
+// Equals apierror.ValidationErrorCodeConfigServiceMismatch
+const ValidationErrorCodeConfigServiceMismatch = apierror.ValidationErrorCodeConfigServiceMismatch
+
+// Equals apierror.ValidationErrorCodeConfigNotExist
+const ValidationErrorCodeConfigNotExist = apierror.ValidationErrorCodeConfigNotExist
+
+// Sentinel errors that an [*Error] matches with [errors.Is].
+var (
+	ErrAuthentication = apierror.ErrAuthentication
+	ErrRateLimited    = apierror.ErrRateLimited
+	ErrValidation     = apierror.ErrValidation
+	ErrNotFound       = apierror.ErrNotFound
+	ErrServer         = apierror.ErrServer
+)
@@ -0,0 +1,49 @@
+package aidr
+
+import (
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// The audit data activity configuration of an audit config, as described by the
+// `aidr-audit-data-activity` schema. The spec publishes no routes for it, so the
+// SDK has no service for it.
+type AuditDataActivity struct {
+	// A service config ID
+	AuditConfigID string `json:"audit_config_id"`
+	Enabled       bool   `json:"enabled"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AuditConfigID respjson.Field
+		Enabled       respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r AuditDataActivity) RawJSON() string { return r.JSON.raw }
+
+func (r *AuditDataActivity) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// An audit data activity configuration, as described by the
+// `aidr-audit-data-activity` schema. Set Enabled to false to disable audit data
+// activity, as an unset Enabled is omitted.
+type AuditDataActivityParams struct {
+	// A service config ID
+	AuditConfigID param.Opt[string] `json:"audit_config_id,omitzero"`
+	Enabled       param.Opt[bool]   `json:"enabled,omitzero"`
+	paramObj
+}
+
+func (r AuditDataActivityParams) MarshalJSON() (data []byte, err error) {
+	type shadow AuditDataActivityParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *AuditDataActivityParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
@@ -0,0 +1,26 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestAuditDataActivity(t *testing.T) {
+	body := marshalObject(t, aidr.AuditDataActivityParams{
+		AuditConfigID: aidr.String("pci_123"),
+		Enabled:       aidr.Bool(false),
+	})
+	if body["audit_config_id"] != "pci_123" || body["enabled"] != false {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var activity aidr.AuditDataActivity
+	if err := json.Unmarshal([]byte(`{"audit_config_id": "pci_123", "enabled": true}`), &activity); err != nil {
+		t.Fatal(err)
+	}
+	if activity.AuditConfigID != "pci_123" || !activity.Enabled {
+		t.Errorf("unexpected audit data activity %+v", activity)
+	}
+}
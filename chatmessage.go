@@ -0,0 +1,603 @@
+package aidr
+
+import (
+	"encoding/json"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// The role of the author of a message.
+type ChatMessageRole string
+
+const (
+	ChatMessageRoleSystem    ChatMessageRole = "system"
+	ChatMessageRoleUser      ChatMessageRole = "user"
+	ChatMessageRoleAssistant ChatMessageRole = "assistant"
+	ChatMessageRoleTool      ChatMessageRole = "tool"
+)
+
+// SystemMessage returns a [ChatMessageParam] with the "system" role and plain
+// text content.
+func SystemMessage(content string) ChatMessageParam {
+	return textMessage(ChatMessageRoleSystem, content)
+}
+
+// UserMessage returns a [ChatMessageParam] with the "user" role and plain text
+// content.
+func UserMessage(content string) ChatMessageParam {
+	return textMessage(ChatMessageRoleUser, content)
+}
+
+// AssistantMessage returns a [ChatMessageParam] with the "assistant" role and
+// plain text content.
+func AssistantMessage(content string) ChatMessageParam {
+	return textMessage(ChatMessageRoleAssistant, content)
+}
+
+// ToolMessage returns a [ChatMessageParam] with the "tool" role, answering the
+// tool call identified by toolCallID.
+func ToolMessage(content, toolCallID string) ChatMessageParam {
+	msg := textMessage(ChatMessageRoleTool, content)
+	msg.ToolCallID = param.NewOpt(toolCallID)
+	return msg
+}
+
+func textMessage(role ChatMessageRole, content string) ChatMessageParam {
+	return ChatMessageParam{
+		Role:    role,
+		Content: ChatMessageContentUnionParam{OfString: param.NewOpt(content)},
+	}
+}
+
+// TextContentPart returns a text [ChatContentPartUnionParam].
+func TextContentPart(text string) ChatContentPartUnionParam {
+	var variant ChatContentPartTextParam
+	variant.Text = text
+	return ChatContentPartUnionParam{OfText: &variant}
+}
+
+// ImageContentPart returns an image [ChatContentPartUnionParam] referencing
+// the given URL, which may also be a base64 encoded data URL.
+func ImageContentPart(url string) ChatContentPartUnionParam {
+	var variant ChatContentPartImageParam
+	variant.ImageURL.URL = url
+	return ChatContentPartUnionParam{OfImageURL: &variant}
+}
+
+// The property Role is required.
+type ChatMessageParam struct {
+	// The role of the author of this message.
+	//
+	// Any of "system", "user", "assistant", "tool".
+	Role ChatMessageRole `json:"role,omitzero,required"`
+	// An optional name for the participant.
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Tool call that this message is responding to. Only used with the "tool" role.
+	ToolCallID param.Opt[string] `json:"tool_call_id,omitzero"`
+	// The contents of the message, either plain text or a list of content parts.
+	Content ChatMessageContentUnionParam `json:"content,omitzero"`
+	// The tool calls generated by the model. Only used with the "assistant" role.
+	ToolCalls []ChatToolCallParam `json:"tool_calls,omitzero"`
+	paramObj
+}
+
+func (r ChatMessageParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatMessageParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatMessageParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type ChatMessageContentUnionParam struct {
+	OfString       param.Opt[string]           `json:",omitzero,inline"`
+	OfContentParts []ChatContentPartUnionParam `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u ChatMessageContentUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfString, u.OfContentParts)
+}
+
+func (u *ChatMessageContentUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+// Only one field can be non-zero.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type ChatContentPartUnionParam struct {
+	OfText     *ChatContentPartTextParam  `json:",omitzero,inline"`
+	OfImageURL *ChatContentPartImageParam `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u ChatContentPartUnionParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfText, u.OfImageURL)
+}
+
+func (u *ChatContentPartUnionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, u)
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u ChatContentPartUnionParam) GetText() *string {
+	if vt := u.OfText; vt != nil {
+		return &vt.Text
+	}
+	return nil
+}
+
+// Returns a pointer to the underlying variant's property, if present.
+func (u ChatContentPartUnionParam) GetImageURL() *ChatContentPartImageImageURLParam {
+	if vt := u.OfImageURL; vt != nil {
+		return &vt.ImageURL
+	}
+	return nil
+}
+
+func init() {
+	apijson.RegisterUnion[ChatContentPartUnionParam](
+		"type",
+		apijson.Discriminator[ChatContentPartTextParam]("text"),
+		apijson.Discriminator[ChatContentPartImageParam]("image_url"),
+	)
+}
+
+// The properties Text, Type are required.
+type ChatContentPartTextParam struct {
+	// The text content.
+	Text string `json:"text,required"`
+	// This field can be elided, and will marshal its zero value as "text".
+	Type string `json:"type,required"`
+	paramObj
+}
+
+func (r ChatContentPartTextParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatContentPartTextParam
+	if r.Type == "" {
+		r.Type = "text"
+	}
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatContentPartTextParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The properties ImageURL, Type are required.
+type ChatContentPartImageParam struct {
+	ImageURL ChatContentPartImageImageURLParam `json:"image_url,omitzero,required"`
+	// This field can be elided, and will marshal its zero value as "image_url".
+	Type string `json:"type,required"`
+	paramObj
+}
+
+func (r ChatContentPartImageParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatContentPartImageParam
+	if r.Type == "" {
+		r.Type = "image_url"
+	}
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatContentPartImageParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property URL is required.
+type ChatContentPartImageImageURLParam struct {
+	// Either a URL of the image or the base64 encoded image data.
+	URL string `json:"url,required" format:"uri"`
+	// Specifies the detail level of the image.
+	//
+	// Any of "auto", "low", "high".
+	Detail ChatContentPartImageImageURLDetail `json:"detail,omitzero"`
+	paramObj
+}
+
+func (r ChatContentPartImageImageURLParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatContentPartImageImageURLParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatContentPartImageImageURLParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Specifies the detail level of the image.
+type ChatContentPartImageImageURLDetail string
+
+const (
+	ChatContentPartImageImageURLDetailAuto ChatContentPartImageImageURLDetail = "auto"
+	ChatContentPartImageImageURLDetailLow  ChatContentPartImageImageURLDetail = "low"
+	ChatContentPartImageImageURLDetailHigh ChatContentPartImageImageURLDetail = "high"
+)
+
+// The properties Function, Type are required.
+type ChatToolParam struct {
+	Function ChatToolFunctionParam `json:"function,omitzero,required"`
+	// This field can be elided, and will marshal its zero value as "function".
+	Type string `json:"type,required"`
+	paramObj
+}
+
+func (r ChatToolParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatToolParam
+	if r.Type == "" {
+		r.Type = "function"
+	}
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatToolParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property Name is required.
+type ChatToolFunctionParam struct {
+	// The name of the function.
+	Name string `json:"name,required"`
+	// A description of what the function does.
+	Description param.Opt[string] `json:"description,omitzero"`
+	// The parameters the function accepts, described as a JSON Schema object.
+	Parameters map[string]any `json:"parameters,omitzero"`
+	paramObj
+}
+
+func (r ChatToolFunctionParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatToolFunctionParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatToolFunctionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The properties ID, Function, Type are required.
+type ChatToolCallParam struct {
+	// The ID of the tool call.
+	ID string `json:"id,required"`
+	// The function that the model called.
+	Function ChatToolCallFunctionParam `json:"function,omitzero,required"`
+	// This field can be elided, and will marshal its zero value as "function".
+	Type string `json:"type,required"`
+	paramObj
+}
+
+func (r ChatToolCallParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatToolCallParam
+	if r.Type == "" {
+		r.Type = "function"
+	}
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatToolCallParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The properties Arguments, Name are required.
+type ChatToolCallFunctionParam struct {
+	// The arguments to call the function with, as JSON generated by the model.
+	Arguments string `json:"arguments,required"`
+	// The name of the function to call.
+	Name string `json:"name,required"`
+	paramObj
+}
+
+func (r ChatToolCallFunctionParam) MarshalJSON() (data []byte, err error) {
+	type shadow ChatToolCallFunctionParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ChatToolCallFunctionParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatMessage struct {
+	// The role of the author of this message.
+	//
+	// Any of "system", "user", "assistant", "tool".
+	Role ChatMessageRole `json:"role,required"`
+	// The contents of the message, either plain text or a list of content parts.
+	Content ChatMessageContentUnion `json:"content"`
+	// An optional name for the participant.
+	Name string `json:"name"`
+	// Tool call that this message is responding to.
+	ToolCallID string `json:"tool_call_id"`
+	// The tool calls generated by the model.
+	ToolCalls []ChatToolCall `json:"tool_calls"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Role        respjson.Field
+		Content     respjson.Field
+		Name        respjson.Field
+		ToolCallID  respjson.Field
+		ToolCalls   respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatMessage) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatMessage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this ChatMessage to a ChatMessageParam.
+//
+// Warning: the fields of the param type will not be present. ToParam should only
+// be used at the last possible moment before sending a request. Test for this
+// with ChatMessageParam.Overrides()
+func (r ChatMessage) ToParam() ChatMessageParam {
+	return param.Override[ChatMessageParam](json.RawMessage(r.RawJSON()))
+}
+
+// ChatMessageContentUnion contains all possible properties and values from
+// [string], [[]ChatContentPartUnion].
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+//
+// If the underlying value is not a json object, one of the following properties
+// will be valid: OfString OfContentParts]
+type ChatMessageContentUnion struct {
+	// This field will be present if the value is a [string] instead of an object.
+	OfString string `json:",inline"`
+	// This field will be present if the value is a [[]ChatContentPartUnion] instead
+	// of an object.
+	OfContentParts []ChatContentPartUnion `json:",inline"`
+	JSON           struct {
+		OfString       respjson.Field
+		OfContentParts respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+func (u ChatMessageContentUnion) AsString() (v string) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ChatMessageContentUnion) AsContentParts() (v []ChatContentPartUnion) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u ChatMessageContentUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *ChatMessageContentUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ChatContentPartUnion contains all possible properties and values from
+// [ChatContentPartText], [ChatContentPartImage].
+//
+// Use the [ChatContentPartUnion.AsAny] method to switch on the variant.
+//
+// Use the methods beginning with 'As' to cast the union to one of its variants.
+type ChatContentPartUnion struct {
+	// This field is from variant [ChatContentPartText].
+	Text string `json:"text"`
+	// Any of "text", "image_url".
+	Type string `json:"type"`
+	// This field is from variant [ChatContentPartImage].
+	ImageURL ChatContentPartImageImageURL `json:"image_url"`
+	JSON     struct {
+		Text     respjson.Field
+		Type     respjson.Field
+		ImageURL respjson.Field
+		raw      string
+	} `json:"-"`
+}
+
+// anyChatContentPart is implemented by each variant of [ChatContentPartUnion] to
+// add type safety for the return type of [ChatContentPartUnion.AsAny]
+type anyChatContentPart interface {
+	implChatContentPartUnion()
+}
+
+func (ChatContentPartText) implChatContentPartUnion()  {}
+func (ChatContentPartImage) implChatContentPartUnion() {}
+
+// Use the following switch statement to find the correct variant
+//
+//	switch variant := ChatContentPartUnion.AsAny().(type) {
+//	case aidr.ChatContentPartText:
+//	case aidr.ChatContentPartImage:
+//	default:
+//	  fmt.Errorf("no variant present")
+//	}
+func (u ChatContentPartUnion) AsAny() anyChatContentPart {
+	switch u.Type {
+	case "text":
+		return u.AsText()
+	case "image_url":
+		return u.AsImageURL()
+	}
+	return nil
+}
+
+func (u ChatContentPartUnion) AsText() (v ChatContentPartText) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+func (u ChatContentPartUnion) AsImageURL() (v ChatContentPartImage) {
+	apijson.UnmarshalRoot(json.RawMessage(u.JSON.raw), &v)
+	return
+}
+
+// Returns the unmodified JSON received from the API
+func (u ChatContentPartUnion) RawJSON() string { return u.JSON.raw }
+
+func (r *ChatContentPartUnion) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatContentPartText struct {
+	// The text content.
+	Text string `json:"text,required"`
+	Type string `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Text        respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatContentPartText) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatContentPartText) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatContentPartImage struct {
+	ImageURL ChatContentPartImageImageURL `json:"image_url,required"`
+	Type     string                       `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ImageURL    respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatContentPartImage) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatContentPartImage) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatContentPartImageImageURL struct {
+	// Either a URL of the image or the base64 encoded image data.
+	URL string `json:"url,required" format:"uri"`
+	// Specifies the detail level of the image.
+	//
+	// Any of "auto", "low", "high".
+	Detail ChatContentPartImageImageURLDetail `json:"detail"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		URL         respjson.Field
+		Detail      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatContentPartImageImageURL) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatContentPartImageImageURL) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatTool struct {
+	Function ChatToolFunction `json:"function,required"`
+	Type     string           `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Function    respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatTool) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatTool) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this ChatTool to a ChatToolParam.
+//
+// Warning: the fields of the param type will not be present. ToParam should only
+// be used at the last possible moment before sending a request. Test for this
+// with ChatToolParam.Overrides()
+func (r ChatTool) ToParam() ChatToolParam {
+	return param.Override[ChatToolParam](json.RawMessage(r.RawJSON()))
+}
+
+type ChatToolFunction struct {
+	// The name of the function.
+	Name string `json:"name,required"`
+	// A description of what the function does.
+	Description string `json:"description"`
+	// The parameters the function accepts, described as a JSON Schema object.
+	Parameters map[string]any `json:"parameters"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Name        respjson.Field
+		Description respjson.Field
+		Parameters  respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatToolFunction) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatToolFunction) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatToolCall struct {
+	// The ID of the tool call.
+	ID string `json:"id,required"`
+	// The function that the model called.
+	Function ChatToolCallFunction `json:"function,required"`
+	Type     string               `json:"type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID          respjson.Field
+		Function    respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatToolCall) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatToolCall) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ChatToolCallFunction struct {
+	// The arguments to call the function with, as JSON generated by the model.
+	Arguments string `json:"arguments,required"`
+	// The name of the function to call.
+	Name string `json:"name,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Arguments   respjson.Field
+		Name        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ChatToolCallFunction) RawJSON() string { return r.JSON.raw }
+
+func (r *ChatToolCallFunction) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
@@ -0,0 +1,155 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+	"github.com/crowdstrike/aidr-go/packages/param"
+)
+
+func TestGuardInputMarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		input aidr.AIGuardGuardChatCompletionsParamsGuardInput
+		want  string
+	}{
+		"string-content": {
+			input: aidr.AIGuardGuardChatCompletionsParamsGuardInput{
+				Messages: []aidr.ChatMessageParam{
+					aidr.SystemMessage("be nice"),
+					aidr.UserMessage("hello"),
+				},
+			},
+			want: `{"messages":[{"role":"system","content":"be nice"},{"role":"user","content":"hello"}]}`,
+		},
+		"content-parts": {
+			input: aidr.AIGuardGuardChatCompletionsParamsGuardInput{
+				Messages: []aidr.ChatMessageParam{{
+					Role: aidr.ChatMessageRoleUser,
+					Content: aidr.ChatMessageContentUnionParam{
+						OfContentParts: []aidr.ChatContentPartUnionParam{
+							aidr.TextContentPart("what is this?"),
+							aidr.ImageContentPart("https://example.com/a.png"),
+						},
+					},
+				}},
+			},
+			want: `{"messages":[{"role":"user","content":[{"text":"what is this?","type":"text"},{"image_url":{"url":"https://example.com/a.png"},"type":"image_url"}]}]}`,
+		},
+		"tools": {
+			input: aidr.AIGuardGuardChatCompletionsParamsGuardInput{
+				Messages: []aidr.ChatMessageParam{
+					{
+						Role: aidr.ChatMessageRoleAssistant,
+						ToolCalls: []aidr.ChatToolCallParam{{
+							ID:       "call_1",
+							Function: aidr.ChatToolCallFunctionParam{Name: "lookup", Arguments: `{"q":"x"}`},
+						}},
+					},
+					aidr.ToolMessage("result", "call_1"),
+				},
+				Tools: []aidr.ChatToolParam{{
+					Function: aidr.ChatToolFunctionParam{Name: "lookup", Description: aidr.String("Looks things up")},
+				}},
+			},
+			want: `{"messages":[{"role":"assistant","tool_calls":[{"id":"call_1","function":{"arguments":"{\"q\":\"x\"}","name":"lookup"},"type":"function"}]},{"role":"tool","tool_call_id":"call_1","content":"result"}],"tools":[{"function":{"name":"lookup","description":"Looks things up"},"type":"function"}]}`,
+		},
+		"override": {
+			input: param.Override[aidr.AIGuardGuardChatCompletionsParamsGuardInput](map[string]any{
+				"messages": []any{map[string]any{"role": "user", "content": "hi"}},
+			}),
+			want: `{"messages":[{"content":"hi","role":"user"}]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(test.input)
+			if err != nil {
+				t.Fatalf("didn't expect error %v", err)
+			}
+			if string(b) != test.want {
+				t.Fatalf("expected %s, received %s", test.want, string(b))
+			}
+		})
+	}
+}
+
+func TestGuardInputUnmarshalJSON(t *testing.T) {
+	raw := `{"messages":[{"role":"user","content":[{"type":"text","text":"hi"},{"type":"image_url","image_url":{"url":"https://example.com/a.png"}}]}]}`
+
+	var input aidr.AIGuardGuardChatCompletionsParamsGuardInput
+	if err := json.Unmarshal([]byte(raw), &input); err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if len(input.Messages) != 1 || input.Messages[0].Role != aidr.ChatMessageRoleUser {
+		t.Fatalf("unexpected messages %#v", input.Messages)
+	}
+	parts := input.Messages[0].Content.OfContentParts
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 content parts, received %d", len(parts))
+	}
+	if text := parts[0].GetText(); text == nil || *text != "hi" {
+		t.Fatalf("expected text part, received %#v", parts[0])
+	}
+	if img := parts[1].GetImageURL(); img == nil || img.URL != "https://example.com/a.png" {
+		t.Fatalf("expected image part, received %#v", parts[1])
+	}
+}
+
+func TestGuardOutputUnmarshalJSON(t *testing.T) {
+	raw := `{
+		"detectors": {},
+		"blocked": true,
+		"guard_output": {
+			"messages": [
+				{"role": "system", "content": "be nice"},
+				{"role": "user", "content": [{"type": "text", "text": "my ssn is <US_SSN>"}]},
+				{"role": "assistant", "tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{}"}}]}
+			]
+		},
+		"access_rules": {
+			"block_large": {"matched": true, "action": "blocked", "name": "Block Large Requests", "logic": {"var": "x"}}
+		}
+	}`
+
+	var result aidr.AIGuardGuardChatCompletionsResponseResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+
+	msgs := result.GuardOutput.Messages
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 messages, received %d", len(msgs))
+	}
+	if msgs[0].Role != aidr.ChatMessageRoleSystem || msgs[0].Content.OfString != "be nice" || !msgs[0].Content.JSON.OfString.Valid() {
+		t.Fatalf("unexpected system message %#v", msgs[0])
+	}
+	parts := msgs[1].Content.OfContentParts
+	if len(parts) != 1 || msgs[1].Content.JSON.OfString.Valid() {
+		t.Fatalf("unexpected user content %#v", msgs[1].Content)
+	}
+	if text, ok := parts[0].AsAny().(aidr.ChatContentPartText); !ok || text.Text != "my ssn is <US_SSN>" {
+		t.Fatalf("unexpected content part %#v", parts[0])
+	}
+	if calls := msgs[2].ToolCalls; len(calls) != 1 || calls[0].Function.Name != "lookup" {
+		t.Fatalf("unexpected tool calls %#v", calls)
+	}
+
+	rule, ok := result.AccessRules["block_large"]
+	if !ok || !rule.Matched || rule.Action != aidr.AccessRuleResultActionBlocked || rule.Name != "Block Large Requests" {
+		t.Fatalf("unexpected access rules %#v", result.AccessRules)
+	}
+	if !rule.JSON.Logic.Valid() || rule.Logic["var"] != "x" {
+		t.Fatalf("unexpected rule logic %#v", rule.Logic)
+	}
+
+	b, err := json.Marshal(result.GuardOutput.ToParam())
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	var roundTrip aidr.AIGuardGuardChatCompletionsParamsGuardInput
+	if err := json.Unmarshal(b, &roundTrip); err != nil || len(roundTrip.Messages) != 3 {
+		t.Fatalf("failed to round trip guard output %s: %v", b, err)
+	}
+}
@@ -0,0 +1,660 @@
+package aidr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+	"github.com/crowdstrike/aidr-go/option"
+)
+
+type closureTransport struct {
+	fn func(req *http.Request) (*http.Response, error)
+}
+
+func (t *closureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.fn(req)
+}
+
+func jsonResponse(req *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}
+}
+
+func guardParams() aidr.AIGuardGuardChatCompletionsParams {
+	return aidr.AIGuardGuardChatCompletionsParams{
+		GuardInput: aidr.AIGuardGuardChatCompletionsParamsGuardInput{
+			Messages: []aidr.ChatMessageParam{aidr.UserMessage("hello")},
+		},
+	}
+}
+
+func TestGuardChatCompletionsAccepted(t *testing.T) {
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(req, http.StatusAccepted, `{
+						"request_id": "prq_123",
+						"request_time": "2022-09-21T17:24:33.105Z",
+						"response_time": "2022-09-21T17:24:34.007Z",
+						"status": "Accepted",
+						"result": {"ttl_mins": 5, "retry_counter": 0, "location": "https://example.com/request/prq_123"}
+					}`), nil
+				},
+			},
+		}),
+	)
+	res, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams())
+	if res != nil {
+		t.Fatalf("expected no response, received %#v", res)
+	}
+	var accepted *aidr.AcceptedError
+	if !errors.As(err, &accepted) {
+		t.Fatalf("expected *aidr.AcceptedError, received %v", err)
+	}
+	if accepted.RequestID != "prq_123" {
+		t.Errorf("expected request ID prq_123, received %q", accepted.RequestID)
+	}
+	if accepted.Location() != "https://example.com/request/prq_123" {
+		t.Errorf("unexpected location %q", accepted.Location())
+	}
+	if accepted.Result.TTLMins != 5 {
+		t.Errorf("expected ttl_mins 5, received %d", accepted.Result.TTLMins)
+	}
+}
+
+func TestGetAsyncRequestAcceptedEmptyBody(t *testing.T) {
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(req, http.StatusAccepted, ""), nil
+				},
+			},
+		}),
+	)
+	_, err := client.AIGuard.GetAsyncRequest(context.Background(), "prq_123")
+	var accepted *aidr.AcceptedError
+	if !errors.As(err, &accepted) {
+		t.Fatalf("expected *aidr.AcceptedError, received %v", err)
+	}
+}
+
+func TestGuardChatCompletionsAndWait(t *testing.T) {
+	polls := 0
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodPost {
+						res := jsonResponse(req, http.StatusAccepted, `{"request_id": "prq_123", "status": "Accepted", "result": {}}`)
+						res.Header.Set("Retry-After-Ms", "1")
+						return res, nil
+					}
+					if req.URL.Path != "/aiguard/request/prq_123" {
+						t.Errorf("unexpected poll path %s", req.URL.Path)
+					}
+					polls++
+					if polls < 3 {
+						res := jsonResponse(req, http.StatusAccepted, `{"request_id": "prq_123", "status": "Accepted", "result": {}}`)
+						res.Header.Set("Retry-After-Ms", "1")
+						return res, nil
+					}
+					return jsonResponse(req, http.StatusOK, `{
+						"request_id": "prq_123",
+						"request_time": "2022-09-21T17:24:33.105Z",
+						"response_time": "2022-09-21T17:24:34.007Z",
+						"status": "Success",
+						"result": {"detectors": {}, "blocked": true}
+					}`), nil
+				},
+			},
+		}),
+	)
+
+	var progress []int
+	res, err := client.AIGuard.GuardChatCompletionsAndWait(context.Background(), guardParams(), aidr.PollOptions{
+		OnPoll: func(p aidr.PollProgress) { progress = append(progress, p.Attempt) },
+	})
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if !res.Result.Blocked || !res.JSON.Result.Valid() || !strings.Contains(res.RawJSON(), `"prq_123"`) {
+		t.Errorf("expected blocked result, received %s", res.RawJSON())
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, made %d", polls)
+	}
+	if len(progress) != 3 || progress[2] != 2 {
+		t.Errorf("unexpected progress callbacks %v", progress)
+	}
+}
+
+func TestGuardChatCompletionsAndWaitFailure(t *testing.T) {
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodPost {
+						res := jsonResponse(req, http.StatusAccepted, `{"request_id": "prq_123", "status": "Accepted", "result": {}}`)
+						res.Header.Set("Retry-After-Ms", "1")
+						return res, nil
+					}
+					return jsonResponse(req, http.StatusOK, `{"request_id": "prq_123", "status": "InternalError", "summary": "processing failed", "result": {}}`), nil
+				},
+			},
+		}),
+	)
+
+	res, err := client.AIGuard.GuardChatCompletionsAndWait(context.Background(), guardParams(), aidr.PollOptions{})
+	if err == nil || !strings.Contains(err.Error(), "InternalError") {
+		t.Fatalf("expected a failure error, received %v and %v", res, err)
+	}
+}
+
+func TestGuardChatCompletionsAndWaitMaxWait(t *testing.T) {
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(req, http.StatusAccepted, `{"request_id": "prq_123", "status": "Accepted", "result": {}}`), nil
+				},
+			},
+		}),
+	)
+
+	_, err := client.AIGuard.GuardChatCompletionsAndWait(context.Background(), guardParams(), aidr.PollOptions{
+		Interval: 10 * time.Millisecond,
+		MaxWait:  50 * time.Millisecond,
+	})
+	var accepted *aidr.AcceptedError
+	if !errors.As(err, &accepted) {
+		t.Fatalf("expected error wrapping *aidr.AcceptedError, received %v", err)
+	}
+}
+
+func TestGuardChatCompletionsAndWaitPollsAtMaxWait(t *testing.T) {
+	polls := 0
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodPost {
+						res := jsonResponse(req, http.StatusAccepted, `{"request_id": "prq_123", "status": "Accepted", "result": {}}`)
+						res.Header.Set("Retry-After-Ms", "10")
+						return res, nil
+					}
+					polls++
+					if polls == 1 {
+						// The next poll would be after MaxWait.
+						res := jsonResponse(req, http.StatusAccepted, `{"request_id": "prq_123", "status": "Accepted", "result": {}}`)
+						res.Header.Set("Retry-After-Ms", "5000")
+						return res, nil
+					}
+					return jsonResponse(req, http.StatusOK, `{"request_id": "prq_123", "status": "Success", "result": {"detectors": {}, "blocked": true}}`), nil
+				},
+			},
+		}),
+	)
+
+	var delays []time.Duration
+	start := time.Now()
+	res, err := client.AIGuard.GuardChatCompletionsAndWait(context.Background(), guardParams(), aidr.PollOptions{
+		MaxWait: 100 * time.Millisecond,
+		OnPoll:  func(p aidr.PollProgress) { delays = append(delays, p.NextPoll) },
+	})
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if !res.Result.Blocked || res.RequestID != "prq_123" {
+		t.Errorf("unexpected result %s", res.RawJSON())
+	}
+	if polls != 2 || len(delays) != 2 || delays[1] > 100*time.Millisecond {
+		t.Errorf("expected a last poll clamped to MaxWait, made %d polls with delays %v", polls, delays)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to stop polling at MaxWait, took %s", elapsed)
+	}
+}
+
+func TestGetAsyncRequestAs(t *testing.T) {
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(req, http.StatusOK, `{
+						"request_id": "prq_123",
+						"request_time": "2022-09-21T17:24:33.105Z",
+						"response_time": "2022-09-21T17:24:34.007Z",
+						"status": "Success",
+						"result": {"detectors": {"topic": {"detected": true, "data": {"topics": [{"topic": "politics", "confidence": 0.9}]}}}, "blocked": true}
+					}`), nil
+				},
+			},
+		}),
+	)
+
+	res, err := aidr.GetAsyncRequestAs[aidr.AIGuardGuardChatCompletionsResponseResult](context.Background(), &client.AIGuard, "prq_123")
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if !res.Status.IsSuccess() || res.Status.IsPending() || res.Status.IsFailure() {
+		t.Errorf("unexpected status %q", res.Status)
+	}
+	if !res.Result.Blocked || res.Result.Detectors.Topic.Data.Topics[0].Topic != "politics" {
+		t.Errorf("unexpected result %s", res.Result.RawJSON())
+	}
+
+	untyped, err := client.AIGuard.GetAsyncRequest(context.Background(), "prq_123")
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	result, err := untyped.AsGuardChatCompletionsResult()
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if !result.Blocked {
+		t.Errorf("unexpected result %s", result.RawJSON())
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	attempts := 0
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithLogger(logger, option.LoggerOptions{Body: option.BodyRedactionHash}),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					if attempts == 1 {
+						res := jsonResponse(req, http.StatusInternalServerError, `{}`)
+						res.Header.Set("Retry-After-Ms", "1")
+						return res, nil
+					}
+					return jsonResponse(req, http.StatusOK, `{"request_id": "prq_123", "status": "Success", "result": {"detectors": {}}}`), nil
+				},
+			},
+		}),
+	)
+	if _, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams()); err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "My Token") || strings.Contains(out, "hello") {
+		t.Fatalf("expected token and prompt to be redacted, received %s", out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one record per attempt, received %s", out)
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if record["attempt"] != float64(2) || record["status"] != float64(200) || record["request_id"] != "prq_123" {
+		t.Errorf("unexpected record %v", record)
+	}
+	if record["path"] != "/aiguard/v1/guard_chat_completions" || record["method"] != http.MethodPost {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestWithLoggerResponseReadError(t *testing.T) {
+	var buf bytes.Buffer
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithMaxRetries(0),
+		option.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)), option.LoggerOptions{}),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					res := jsonResponse(req, http.StatusOK, "")
+					res.Body = io.NopCloser(io.MultiReader(strings.NewReader(`{"status": `), errorReader{}))
+					return res, nil
+				},
+			},
+		}),
+	)
+	_, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams())
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("expected the read error to reach the caller, received %v", err)
+	}
+	if !strings.Contains(buf.String(), "connection reset") {
+		t.Errorf("expected the read error to be logged, received %s", buf.String())
+	}
+}
+
+func TestWithLoggerDefaultAndLargeBody(t *testing.T) {
+	result := `{"detectors": {}, "padding": "` + strings.Repeat("x", 4096) + `"}`
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithLogger(nil, option.LoggerOptions{}),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(req, http.StatusOK, `{"request_id": "prq_123", "status": "Success", "result": `+result+`}`), nil
+				},
+			},
+		}),
+	)
+
+	// The default logger is resolved when the request is made.
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	res, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams())
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if res.Result.RawJSON() != result {
+		t.Errorf("expected the whole body to reach the caller, received %d bytes", len(res.Result.RawJSON()))
+	}
+	if out := buf.String(); !strings.Contains(out, `"request_id":"prq_123"`) || strings.Contains(out, "xxxx") {
+		t.Errorf("unexpected record %s", out)
+	}
+}
+
+func TestWithDebugLog(t *testing.T) {
+	var buf bytes.Buffer
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithDebugLog(log.New(&buf, "", 0)),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(req, http.StatusOK, `{"request_id": "prq_123", "status": "Success", "result": {"detectors": {}, "output": {"reply": "secret answer"}}}`), nil
+				},
+			},
+		}),
+	)
+	res, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams())
+	if err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if res.RequestID != "prq_123" {
+		t.Errorf("expected the body to reach the caller, received %s", res.RawJSON())
+	}
+	out := buf.String()
+	if strings.Contains(out, "My Token") || strings.Contains(out, "hello") || strings.Contains(out, "secret answer") {
+		t.Fatalf("expected token and bodies to be redacted, received %s", out)
+	}
+	if strings.Count(out, "sha256:") != 2 {
+		t.Errorf("expected hashed request and response bodies, received %s", out)
+	}
+}
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.delays = append(c.delays, d)
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	attempts := 0
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var events []option.RetryEvent
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithMaxRetries(5),
+		option.WithRetryPolicy(option.RetryPolicy{
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			BaseDelay:            time.Second,
+			MaxDelay:             3 * time.Second,
+			Jitter:               option.JitterNone,
+			MaxElapsed:           7 * time.Second,
+			OnRetry:              func(e option.RetryEvent) { events = append(events, e) },
+			Clock:                clock,
+		}),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return jsonResponse(req, http.StatusServiceUnavailable, `{}`), nil
+				},
+			},
+		}),
+	)
+	_, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams())
+	if !errors.Is(err, aidr.ErrServer) {
+		t.Fatalf("expected server error, received %v", err)
+	}
+	// 1s + 2s + 3s fit in MaxElapsed, another 3s would not.
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	if len(clock.delays) != len(want) {
+		t.Fatalf("expected delays %v, received %v", want, clock.delays)
+	}
+	for i := range want {
+		if clock.delays[i] != want[i] || events[i].Delay != want[i] || events[i].Attempt != i+1 {
+			t.Fatalf("expected delays %v, received %v (events %v)", want, clock.delays, events)
+		}
+	}
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, made %d", attempts)
+	}
+}
+
+func TestRetryWaitObservesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					res := jsonResponse(req, http.StatusTooManyRequests, `{}`)
+					res.Header.Set("Retry-After", "30")
+					time.AfterFunc(10*time.Millisecond, cancel)
+					return res, nil
+				},
+			},
+		}),
+	)
+	start := time.Now()
+	_, err := client.AIGuard.GuardChatCompletions(ctx, guardParams())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, received %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected cancellation to interrupt the backoff, waited %s", elapsed)
+	}
+}
+
+func TestRetryBudgetUsesPolicyClock(t *testing.T) {
+	attempts := 0
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithToken("My Token"),
+		option.WithMaxRetries(3),
+		option.WithRetryPolicy(option.RetryPolicy{
+			BaseDelay: time.Second,
+			MaxDelay:  time.Second,
+			Jitter:    option.JitterNone,
+			Budget:    option.NewRetryBudget(1, time.Second),
+			Clock:     clock,
+		}),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return jsonResponse(req, http.StatusServiceUnavailable, `{}`), nil
+				},
+			},
+		}),
+	)
+	if _, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams()); err == nil {
+		t.Fatalf("expected an error")
+	}
+	// The budget refills by one retry for every second waited on the clock.
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, made %d", attempts)
+	}
+}
+
+func TestTokenProviderRetriesUnauthorizedOnce(t *testing.T) {
+	fetches := 0
+	provider := option.NewRefreshingTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		return fmt.Sprintf("token-%d", fetches), time.Time{}, nil
+	}, 0)
+
+	var seen []string
+	client := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithTokenProvider(provider),
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					seen = append(seen, req.Header.Get("Authorization"))
+					if req.Header.Get("Authorization") == "Bearer token-1" {
+						return jsonResponse(req, http.StatusUnauthorized, `{"status":"Unauthorized"}`), nil
+					}
+					return jsonResponse(req, http.StatusOK, `{"status":"Success","result":{"blocked":false}}`), nil
+				},
+			},
+		}),
+	)
+	res, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams())
+	if err != nil {
+		t.Fatalf("expected the retry with a fresh token to succeed, received %v", err)
+	}
+	if res.Status != "Success" {
+		t.Errorf("unexpected status %q", res.Status)
+	}
+	if want := []string{"Bearer token-1", "Bearer token-2"}; !slices.Equal(seen, want) {
+		t.Errorf("expected %v, received %v", want, seen)
+	}
+
+	// A static token cannot be refreshed, so a 401 is returned as is.
+	seen = nil
+	_, err = client.AIGuard.GuardChatCompletions(context.Background(), guardParams(), option.WithToken("token-1"))
+	if !errors.Is(err, aidr.ErrAuthentication) {
+		t.Fatalf("expected an authentication error, received %v", err)
+	}
+	if len(seen) != 1 {
+		t.Errorf("expected a single attempt, made %d", len(seen))
+	}
+}
+
+func TestWithFailover(t *testing.T) {
+	var hosts []string
+	failover := option.NewFailover(time.Minute, "http://primary/{SERVICE_NAME}", "http://secondary/{SERVICE_NAME}")
+	client := aidr.NewClient(
+		option.WithToken("My Token"),
+		option.WithMaxRetries(0),
+		option.WithFailover(failover),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					hosts = append(hosts, req.URL.Host+req.URL.Path)
+					if req.URL.Host == "primary" {
+						return jsonResponse(req, http.StatusBadGateway, `{}`), nil
+					}
+					return jsonResponse(req, http.StatusOK, `{"status":"Success","result":{"blocked":false}}`), nil
+				},
+			},
+		}),
+	)
+
+	for range 2 {
+		if _, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams()); err != nil {
+			t.Fatalf("expected the secondary endpoint to answer, received %v", err)
+		}
+	}
+	want := []string{
+		"primary/aiguard/v1/guard_chat_completions",
+		"secondary/aiguard/v1/guard_chat_completions",
+		// The primary endpoint is cooling down.
+		"secondary/aiguard/v1/guard_chat_completions",
+	}
+	if !slices.Equal(hosts, want) {
+		t.Fatalf("expected %v, received %v", want, hosts)
+	}
+	if endpoints := failover.Endpoints(); endpoints[0].Healthy || !endpoints[1].Healthy {
+		t.Errorf("unexpected endpoints %+v", endpoints)
+	}
+}
+
+func TestWithRegion(t *testing.T) {
+	var url string
+	client := aidr.NewClient(
+		option.WithToken("My Token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					url = req.URL.String()
+					return jsonResponse(req, http.StatusOK, `{"status":"Success"}`), nil
+				},
+			},
+		}),
+	)
+	for region, want := range map[option.Region]string{
+		option.RegionAWSUS:      "https://aidr.aws.us.pangea.cloud/v1/guard_chat_completions",
+		option.RegionAWSUSWest2: "https://aidr.aws.us-west-2.pangea.cloud/v1/guard_chat_completions",
+		option.RegionEU1:        "https://api.eu-1.crowdstrike.com/aidr/aiguard/v1/guard_chat_completions",
+	} {
+		if _, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams(), option.WithRegion(region)); err != nil {
+			t.Fatal(err)
+		}
+		if url != want {
+			t.Errorf("%s: expected %s, received %s", region, want, url)
+		}
+	}
+
+	_, err := client.AIGuard.GuardChatCompletions(context.Background(), guardParams(), option.WithRegion("mars-1"))
+	if err == nil || !strings.Contains(err.Error(), "unknown region") {
+		t.Errorf("expected an unknown region error, received %v", err)
+	}
+}
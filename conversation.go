@@ -0,0 +1,172 @@
+package aidr
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/option"
+)
+
+// Conversation guards the turns of one conversation with
+// [AIGuardService.GuardChatCompletions]. It holds the request metadata once and
+// sends the growing message history with every turn, each with the event type of
+// the turn.
+//
+// A Conversation is JSON-serializable, so a session can be stored between
+// stateless HTTP handlers and restored with [AIGuardService.ResumeConversation].
+// It is not safe for concurrent use.
+type Conversation struct {
+	// Metadata is sent with every turn, such as AppID, UserID, TenantID and
+	// ExtraInfo. Its GuardInput and EventType are set by each turn.
+	Metadata AIGuardGuardChatCompletionsParams `json:"metadata"`
+	// Messages is the history of the allowed turns. When the API transforms the
+	// input, for instance to redact it, the history is replaced by the transformed
+	// messages.
+	Messages []ChatMessageParam `json:"messages,omitempty"`
+	// Tools available to the model, sent with every turn.
+	Tools []ChatToolParam `json:"tools,omitempty"`
+	// FpeContext is the latest FPE context returned by the API, to unredact the
+	// output of the model.
+	FpeContext string `json:"fpe_context,omitempty"`
+	// Turns is the audit trail of the conversation, with one entry per guarded
+	// turn, in order.
+	Turns []ConversationTurn `json:"turns,omitempty"`
+
+	service *AIGuardService
+}
+
+// ConversationTurn is the audit trail entry of one turn of a [Conversation].
+type ConversationTurn struct {
+	EventType AIGuardGuardChatCompletionsParamsEventType `json:"event_type"`
+	// Messages are the messages added by the turn, as given.
+	Messages  []ChatMessageParam `json:"messages"`
+	RequestID string             `json:"request_id,omitempty"`
+	// RequestTime is zero when the request failed.
+	RequestTime time.Time `json:"request_time,omitzero"`
+	// Result is zero when the request failed.
+	Result AIGuardGuardChatCompletionsResponseResult `json:"-"`
+	// Error is the error of the request, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+func (r ConversationTurn) MarshalJSON() ([]byte, error) {
+	type shadow ConversationTurn
+	var result json.RawMessage
+	if raw := r.Result.RawJSON(); raw != "" {
+		result = json.RawMessage(raw)
+	}
+	return json.Marshal(struct {
+		shadow
+		Result json.RawMessage `json:"result,omitempty"`
+	}{shadow(r), result})
+}
+
+func (r *ConversationTurn) UnmarshalJSON(data []byte) error {
+	type shadow ConversationTurn
+	var v struct {
+		shadow
+		Result json.RawMessage `json:"result,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = ConversationTurn(v.shadow)
+	if len(v.Result) > 0 {
+		return json.Unmarshal(v.Result, &r.Result)
+	}
+	return nil
+}
+
+// Blocked reports whether the turn was blocked.
+func (r ConversationTurn) Blocked() bool { return r.Result.Blocked }
+
+// NewConversation returns a [Conversation] whose turns are guarded by this
+// service and sent with the given metadata.
+func (r *AIGuardService) NewConversation(metadata AIGuardGuardChatCompletionsParams) *Conversation {
+	return &Conversation{Metadata: metadata, service: r}
+}
+
+// ResumeConversation restores a [Conversation] serialized as JSON, and binds it
+// to this service.
+func (r *AIGuardService) ResumeConversation(data []byte) (*Conversation, error) {
+	c := &Conversation{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	c.service = r
+	return c, nil
+}
+
+// SendUser guards a user prompt, as an "input" event.
+func (c *Conversation) SendUser(ctx context.Context, content string, opts ...option.RequestOption) (*AIGuardGuardChatCompletionsResponse, error) {
+	return c.Send(ctx, AIGuardGuardChatCompletionsParamsEventTypeInput, []ChatMessageParam{UserMessage(content)}, opts...)
+}
+
+// SendAssistant guards a response of the model, as an "output" event.
+func (c *Conversation) SendAssistant(ctx context.Context, content string, opts ...option.RequestOption) (*AIGuardGuardChatCompletionsResponse, error) {
+	return c.Send(ctx, AIGuardGuardChatCompletionsParamsEventTypeOutput, []ChatMessageParam{AssistantMessage(content)}, opts...)
+}
+
+// SendToolCalls guards the tool calls requested by the model, as a "tool_input"
+// event.
+func (c *Conversation) SendToolCalls(ctx context.Context, calls []ChatToolCallParam, opts ...option.RequestOption) (*AIGuardGuardChatCompletionsResponse, error) {
+	msg := ChatMessageParam{Role: ChatMessageRoleAssistant, ToolCalls: calls}
+	return c.Send(ctx, AIGuardGuardChatCompletionsParamsEventTypeToolInput, []ChatMessageParam{msg}, opts...)
+}
+
+// SendToolResult guards the result of the tool call identified by toolCallID, as
+// a "tool_output" event.
+func (c *Conversation) SendToolResult(ctx context.Context, content, toolCallID string, opts ...option.RequestOption) (*AIGuardGuardChatCompletionsResponse, error) {
+	return c.Send(ctx, AIGuardGuardChatCompletionsParamsEventTypeToolOutput, []ChatMessageParam{ToolMessage(content, toolCallID)}, opts...)
+}
+
+// Send guards a turn made of msgs, sent after the history with the given event
+// type. The turn is recorded in Turns whatever the outcome. Its messages are only
+// added to the history when the request succeeds and the turn is not blocked, so
+// that a blocked prompt is not sent again with the next turns.
+func (c *Conversation) Send(ctx context.Context, eventType AIGuardGuardChatCompletionsParamsEventType, msgs []ChatMessageParam, opts ...option.RequestOption) (*AIGuardGuardChatCompletionsResponse, error) {
+	messages := slices.Concat(c.Messages, msgs)
+	body := c.Metadata
+	body.EventType = eventType
+	body.GuardInput = AIGuardGuardChatCompletionsParamsGuardInput{
+		Messages: messages,
+		Tools:    c.Tools,
+	}
+
+	turn := ConversationTurn{EventType: eventType, Messages: msgs}
+	res, err := c.service.GuardChatCompletions(ctx, body, opts...)
+	if err != nil {
+		turn.Error = err.Error()
+		c.Turns = append(c.Turns, turn)
+		return res, err
+	}
+	turn.RequestID = res.RequestID
+	turn.RequestTime = res.RequestTime
+	turn.Result = res.Result
+	c.Turns = append(c.Turns, turn)
+
+	if res.Result.FpeContext != "" {
+		c.FpeContext = res.Result.FpeContext
+	}
+	if res.Result.Blocked {
+		return res, nil
+	}
+	if res.Result.Transformed && len(res.Result.GuardOutput.Messages) > 0 {
+		messages = make([]ChatMessageParam, len(res.Result.GuardOutput.Messages))
+		for i, msg := range res.Result.GuardOutput.Messages {
+			messages[i] = msg.ToParam()
+		}
+	}
+	c.Messages = messages
+	return res, nil
+}
+
+// LastTurn returns the latest turn of the conversation, if any.
+func (c *Conversation) LastTurn() (ConversationTurn, bool) {
+	if len(c.Turns) == 0 {
+		return ConversationTurn{}, false
+	}
+	return c.Turns[len(c.Turns)-1], true
+}
@@ -0,0 +1,187 @@
+package aidr
+
+import (
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A custom list, as described by the `aidr-customlist-result` schema. The spec
+// publishes no routes for custom lists, so the SDK has no service for them.
+type CustomList struct {
+	// Unique identifier for the list
+	ID string `json:"id"`
+	// Content of the list. The spec leaves the shape of entries to the list type.
+	Content []map[string]any `json:"content"`
+	// Name of the list
+	Name string `json:"name"`
+	// Type of the list
+	//
+	// Any of "site".
+	Type      CustomListType `json:"type"`
+	CreatedAt time.Time      `json:"created_at" format:"date-time"`
+	UpdatedAt time.Time      `json:"updated_at" format:"date-time"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID          respjson.Field
+		Content     respjson.Field
+		Name        respjson.Field
+		Type        respjson.Field
+		CreatedAt   respjson.Field
+		UpdatedAt   respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r CustomList) RawJSON() string { return r.JSON.raw }
+
+func (r *CustomList) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Type of the list
+type CustomListType string
+
+const (
+	CustomListTypeSite CustomListType = "site"
+)
+
+// A page of custom lists, as described by the `aidr-customlist-search-result`
+// schema.
+type CustomListSearchResult struct {
+	// Total number of lists
+	Count int64 `json:"count"`
+	// Pagination cursor
+	Last  string       `json:"last"`
+	Lists []CustomList `json:"lists"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count       respjson.Field
+		Last        respjson.Field
+		Lists       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r CustomListSearchResult) RawJSON() string { return r.JSON.raw }
+
+func (r *CustomListSearchResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A custom list definition, as described by the `aidr-customlist` schema.
+type CustomListParams struct {
+	// Name of the list
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Type of the list
+	//
+	// Any of "site".
+	Type CustomListType `json:"type,omitzero"`
+	// Content of the list based on type
+	Content []map[string]any `json:"content,omitzero"`
+	paramObj
+}
+
+func (r CustomListParams) MarshalJSON() (data []byte, err error) {
+	type shadow CustomListParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *CustomListParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Filters for listing custom lists, as described by the `aidr-customlist-search`
+// schema.
+type CustomListSearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]             `json:"size,omitzero"`
+	Filter CustomListSearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order CustomListSearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "id", "name", "created_at", "updated_at".
+	OrderBy CustomListSearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r CustomListSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow CustomListSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *CustomListSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type CustomListSearchParamsFilter struct {
+	// Only records where created_at equals this value.
+	CreatedAt param.Opt[time.Time] `json:"created_at,omitzero" format:"date-time"`
+	// Only records where created_at is greater than this value.
+	CreatedAtGt param.Opt[time.Time] `json:"created_at__gt,omitzero" format:"date-time"`
+	// Only records where created_at is greater than or equal to this value.
+	CreatedAtGte param.Opt[time.Time] `json:"created_at__gte,omitzero" format:"date-time"`
+	// Only records where created_at is less than this value.
+	CreatedAtLt param.Opt[time.Time] `json:"created_at__lt,omitzero" format:"date-time"`
+	// Only records where created_at is less than or equal to this value.
+	CreatedAtLte param.Opt[time.Time] `json:"created_at__lte,omitzero" format:"date-time"`
+	// Only records where name is equal to the value
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Only records where updated_at equals this value.
+	UpdatedAt param.Opt[time.Time] `json:"updated_at,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than this value.
+	UpdatedAtGt param.Opt[time.Time] `json:"updated_at__gt,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than or equal to this value.
+	UpdatedAtGte param.Opt[time.Time] `json:"updated_at__gte,omitzero" format:"date-time"`
+	// Only records where updated_at is less than this value.
+	UpdatedAtLt param.Opt[time.Time] `json:"updated_at__lt,omitzero" format:"date-time"`
+	// Only records where updated_at is less than or equal to this value.
+	UpdatedAtLte param.Opt[time.Time] `json:"updated_at__lte,omitzero" format:"date-time"`
+	// Only records where name includes each substring.
+	NameContains []string `json:"name__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	NameIn []string `json:"name__in,omitzero"`
+	// Only records where type is equal to the value
+	//
+	// Any of "site".
+	Type CustomListType `json:"type,omitzero"`
+	paramObj
+}
+
+func (r CustomListSearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow CustomListSearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *CustomListSearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type CustomListSearchParamsOrder string
+
+const (
+	CustomListSearchParamsOrderAsc  CustomListSearchParamsOrder = "asc"
+	CustomListSearchParamsOrderDesc CustomListSearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type CustomListSearchParamsOrderBy string
+
+const (
+	CustomListSearchParamsOrderByID        CustomListSearchParamsOrderBy = "id"
+	CustomListSearchParamsOrderByName      CustomListSearchParamsOrderBy = "name"
+	CustomListSearchParamsOrderByCreatedAt CustomListSearchParamsOrderBy = "created_at"
+	CustomListSearchParamsOrderByUpdatedAt CustomListSearchParamsOrderBy = "updated_at"
+)
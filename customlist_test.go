@@ -0,0 +1,58 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+const customListJSON = `{
+	"id": "list_1",
+	"name": "Competitors",
+	"type": "site",
+	"content": [
+		{"url": "a.example.com", "description": "A", "category": "retail"},
+		{"url": "b.example.com"}
+	]
+}`
+
+func TestCustomList(t *testing.T) {
+	var res aidr.CustomListSearchResult
+	if err := json.Unmarshal([]byte(`{"count": 1, "lists": [`+customListJSON+`]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	list := res.Lists[0]
+	if list.Type != aidr.CustomListTypeSite || len(list.Content) != 2 {
+		t.Fatalf("unexpected list %+v", list)
+	}
+	// Entries are kept as they were received.
+	if list.Content[0]["category"] != "retail" || list.Content[1]["url"] != "b.example.com" {
+		t.Errorf("unexpected content %v", list.Content)
+	}
+
+	// A retrieved list can be sent back with an additional entry.
+	body := marshalObject(t, aidr.CustomListParams{
+		Name:    aidr.String(list.Name),
+		Type:    list.Type,
+		Content: append(list.Content, map[string]any{"url": "c.example.com"}),
+	})
+	content := body["content"].([]any)
+	if body["name"] != "Competitors" || body["type"] != "site" || len(content) != 3 {
+		t.Fatalf("unexpected body %v", body)
+	}
+	if content[0].(map[string]any)["category"] != "retail" || content[2].(map[string]any)["url"] != "c.example.com" {
+		t.Errorf("unexpected content %v", content)
+	}
+}
+
+func TestCustomListSearchParams(t *testing.T) {
+	body := marshalObject(t, aidr.CustomListSearchParams{
+		Filter:  aidr.CustomListSearchParamsFilter{Type: aidr.CustomListTypeSite, NameContains: []string{"Comp"}},
+		OrderBy: aidr.CustomListSearchParamsOrderByName,
+	})
+	filter := body["filter"].(map[string]any)
+	if filter["type"] != "site" || filter["name__contains"].([]any)[0] != "Comp" || body["order_by"] != "name" {
+		t.Errorf("unexpected body %v", body)
+	}
+}
@@ -0,0 +1,148 @@
+package aidr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DecisionRules is a declarative rule set evaluated locally against a guard
+// result, to apply stricter limits than the policy of the server. The zero value
+// has no rules. DecisionRules can be loaded from JSON.
+type DecisionRules struct {
+	// ConfidenceThresholds blocks the findings of a detector, keyed by detector such
+	// as "malicious_prompt" or "topic", whose confidence is at least the
+	// threshold. The analyzers and topics are checked even when the detector did not
+	// flag them. Findings without a confidence are never blocked by a threshold.
+	ConfidenceThresholds map[string]float64 `json:"confidence_thresholds,omitempty"`
+	// TopicDenyList blocks the topics in the list, whatever their confidence and
+	// whether the detector flagged them or not.
+	TopicDenyList []string `json:"topic_deny_list,omitempty"`
+	// EntityTypeBlocklist blocks the entities of the given types, such as
+	// "EMAIL_ADDRESS", whatever their detector.
+	EntityTypeBlocklist []string `json:"entity_type_blocklist,omitempty"`
+	// LanguageAllowList blocks the inputs whose detected language is not in the
+	// list. An empty list allows every language.
+	LanguageAllowList []string `json:"language_allow_list,omitempty"`
+}
+
+// The kind of a rule of [DecisionRules].
+type DecisionRule string
+
+const (
+	// The server blocked the input.
+	DecisionRuleServerBlocked       DecisionRule = "server_blocked"
+	DecisionRuleConfidenceThreshold DecisionRule = "confidence_threshold"
+	DecisionRuleTopicDenyList       DecisionRule = "topic_deny_list"
+	DecisionRuleEntityTypeBlocklist DecisionRule = "entity_type_blocklist"
+	DecisionRuleLanguageAllowList   DecisionRule = "language_allow_list"
+)
+
+// Verdict is the outcome of [DecisionRules.Evaluate].
+type Verdict struct {
+	// Blocked is true when the server blocked the input or any rule triggered.
+	Blocked bool
+	// Triggered lists the rules that triggered, in the order they were evaluated.
+	Triggered []TriggeredRule
+}
+
+// TriggeredRule is a rule that triggered on a finding.
+type TriggeredRule struct {
+	Rule DecisionRule
+	// Finding is the finding the rule triggered on. It is zero for
+	// [DecisionRuleServerBlocked].
+	Finding Finding
+	// Reason describes why the rule triggered.
+	Reason string
+}
+
+// Evaluate applies the rules to the findings of result. The Blocked flag of the
+// server is a floor: a result blocked by the server is always blocked, and the
+// rules can only block more.
+func (r DecisionRules) Evaluate(result AIGuardGuardChatCompletionsResponseResult) Verdict {
+	var v Verdict
+	if result.Blocked {
+		v.Triggered = append(v.Triggered, TriggeredRule{
+			Rule:   DecisionRuleServerBlocked,
+			Reason: "blocked by policy " + result.Policy,
+		})
+	}
+
+	for f := range result.Detectors.Findings() {
+		if f.EntityType != "" && containsFold(r.EntityTypeBlocklist, f.EntityType) {
+			v.Triggered = append(v.Triggered, TriggeredRule{
+				Rule:    DecisionRuleEntityTypeBlocklist,
+				Finding: f,
+				Reason:  fmt.Sprintf("entity type %s is blocked", f.EntityType),
+			})
+		}
+	}
+
+	// Analyzers and topics are read whatever Detected says, since the rules are
+	// meant to be stricter than the thresholds of the server.
+	for _, f := range scoredFindings(result.Detectors) {
+		if threshold, ok := r.ConfidenceThresholds[f.Detector]; ok && f.Confidence > 0 && f.Confidence >= threshold {
+			v.Triggered = append(v.Triggered, TriggeredRule{
+				Rule:    DecisionRuleConfidenceThreshold,
+				Finding: f,
+				Reason:  fmt.Sprintf("%s %q has confidence %g, at least %g", f.Detector, f.Value, f.Confidence, threshold),
+			})
+		}
+		if f.Detector == "topic" && containsFold(r.TopicDenyList, f.Value) {
+			v.Triggered = append(v.Triggered, TriggeredRule{
+				Rule:    DecisionRuleTopicDenyList,
+				Finding: f,
+				Reason:  fmt.Sprintf("topic %q is denied", f.Value),
+			})
+		}
+	}
+
+	// The language is checked even when the detector did not flag it, since an
+	// allowed language is usually not a detection.
+	if lang := result.Detectors.Language.Data.Language; len(r.LanguageAllowList) > 0 && lang != "" && !containsFold(r.LanguageAllowList, lang) {
+		data := result.Detectors.Language.Data
+		v.Triggered = append(v.Triggered, TriggeredRule{
+			Rule: DecisionRuleLanguageAllowList,
+			Finding: Finding{
+				Detector: "language",
+				Action:   ParseFindingAction(data.Action),
+				Value:    lang,
+				StartPos: -1,
+				Raw:      data.RawJSON(),
+			},
+			Reason: fmt.Sprintf("language %q is not allowed", lang),
+		})
+	}
+
+	v.Blocked = len(v.Triggered) > 0
+	return v
+}
+
+// scoredFindings returns the findings with a confidence: the analyzers of the
+// malicious prompt detector and the topics, whether detected or not, followed by
+// those of the other detectors.
+func scoredFindings(detectors AIGuardGuardChatCompletionsResponseResultDetectors) []Finding {
+	var res []Finding
+	prompt := detectors.MaliciousPrompt.Data
+	for _, a := range prompt.AnalyzerResponses {
+		res = append(res, Finding{Detector: "malicious_prompt", Action: ParseFindingAction(prompt.Action), Value: a.Analyzer, StartPos: -1, Confidence: a.Confidence, Raw: a.RawJSON()})
+	}
+	topic := detectors.Topic.Data
+	for _, t := range topic.Topics {
+		res = append(res, Finding{Detector: "topic", Action: ParseFindingAction(topic.Action), Value: t.Topic, StartPos: -1, Confidence: t.Confidence, Raw: t.RawJSON()})
+	}
+	for f := range detectors.Findings() {
+		if f.Detector != "malicious_prompt" && f.Detector != "topic" && f.Confidence > 0 {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+const decisionResult = `{
+	"blocked": false,
+	"policy": "chat",
+	"detectors": {
+		"confidential_and_pii_entity": {"detected": true, "data": {"entities": [{"action": "redacted", "type": "EMAIL_ADDRESS", "value": "a@b.c"}]}},
+		"language": {"detected": false, "data": {"language": "fr"}},
+		"malicious_prompt": {"detected": true, "data": {"action": "reported", "analyzer_responses": [{"analyzer": "PA4002", "confidence": 0.6}]}},
+		"topic": {"detected": true, "data": {"topics": [{"topic": "politics", "confidence": 0.3}, {"topic": "sports", "confidence": 0.9}]}}
+	}
+}`
+
+func TestDecisionRulesEvaluate(t *testing.T) {
+	var result aidr.AIGuardGuardChatCompletionsResponseResult
+	if err := json.Unmarshal([]byte(decisionResult), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	var rules aidr.DecisionRules
+	err := json.Unmarshal([]byte(`{
+		"confidence_thresholds": {"malicious_prompt": 0.5, "topic": 0.95},
+		"topic_deny_list": ["Politics"],
+		"entity_type_blocklist": ["email_address"],
+		"language_allow_list": ["en"]
+	}`), &rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := rules.Evaluate(result)
+	if !v.Blocked {
+		t.Error("expected the verdict to be blocked")
+	}
+	var got []aidr.DecisionRule
+	for _, tr := range v.Triggered {
+		got = append(got, tr.Rule)
+	}
+	want := []aidr.DecisionRule{
+		aidr.DecisionRuleEntityTypeBlocklist,
+		aidr.DecisionRuleConfidenceThreshold,
+		aidr.DecisionRuleTopicDenyList,
+		aidr.DecisionRuleLanguageAllowList,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected triggered rules %v, want %v", got, want)
+	}
+	if f := v.Triggered[1].Finding; f.Value != "PA4002" || f.Confidence != 0.6 {
+		t.Errorf("unexpected finding %+v", f)
+	}
+	if f := v.Triggered[3].Finding; f.Value != "fr" {
+		t.Errorf("unexpected language finding %+v", f)
+	}
+}
+
+func TestDecisionRulesServerBlockedIsFloor(t *testing.T) {
+	var result aidr.AIGuardGuardChatCompletionsResponseResult
+	if err := json.Unmarshal([]byte(decisionResult), &result); err != nil {
+		t.Fatal(err)
+	}
+	if v := (aidr.DecisionRules{}).Evaluate(result); v.Blocked || len(v.Triggered) != 0 {
+		t.Errorf("expected no rules to trigger, got %+v", v)
+	}
+
+	result.Blocked = true
+	v := (aidr.DecisionRules{LanguageAllowList: []string{"FR"}}).Evaluate(result)
+	if !v.Blocked || len(v.Triggered) != 1 || v.Triggered[0].Rule != aidr.DecisionRuleServerBlocked {
+		t.Errorf("expected the server block to be honored, got %+v", v)
+	}
+}
+
+func TestDecisionRulesEvaluateUndetected(t *testing.T) {
+	var result aidr.AIGuardGuardChatCompletionsResponseResult
+	err := json.Unmarshal([]byte(`{
+		"blocked": false,
+		"detectors": {
+			"malicious_prompt": {"detected": false, "data": {"analyzer_responses": [{"analyzer": "PA4002", "confidence": 0.7}]}},
+			"topic": {"detected": false, "data": {"topics": [{"topic": "politics", "confidence": 0.2}]}}
+		}
+	}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := aidr.DecisionRules{
+		ConfidenceThresholds: map[string]float64{"malicious_prompt": 0.5},
+		TopicDenyList:        []string{"politics"},
+	}.Evaluate(result)
+	var got []aidr.DecisionRule
+	for _, tr := range v.Triggered {
+		got = append(got, tr.Rule)
+	}
+	want := []aidr.DecisionRule{aidr.DecisionRuleConfidenceThreshold, aidr.DecisionRuleTopicDenyList}
+	if !v.Blocked || !slices.Equal(got, want) {
+		t.Fatalf("unexpected verdict %+v, want rules %v", v, want)
+	}
+	if f := v.Triggered[0].Finding; f.Value != "PA4002" || f.Confidence != 0.7 {
+		t.Errorf("unexpected finding %+v", f)
+	}
+}
@@ -0,0 +1,270 @@
+package aidr
+
+import (
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A device, as described by the `aidr-device-result` schema. The spec publishes
+// no routes for devices, so the SDK has no service for them. See
+// [DeviceSession] to keep the access token of a device fresh.
+type Device struct {
+	// client generated unique ID.
+	ID string `json:"id,required"`
+	// Device status. Allowed values are active, pending, disabled
+	//
+	// Any of "pending", "active", "disabled".
+	Status DeviceStatus `json:"status,required"`
+	// Timestamp when the record was created (RFC 3339 format)
+	CreatedAt time.Time `json:"created_at" format:"date-time"`
+	// Last observed IP address for this device.
+	LastUsedIP string `json:"last_used_ip"`
+	// Arbitrary device metadata.
+	Metadata map[string]any `json:"metadata"`
+	// Device name
+	Name string `json:"name"`
+	// Timestamp when the record was last updated (RFC 3339 format)
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+	// Owning user identifier (UUID/string).
+	UserID string `json:"user_id"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID          respjson.Field
+		Status      respjson.Field
+		CreatedAt   respjson.Field
+		LastUsedIP  respjson.Field
+		Metadata    respjson.Field
+		Name        respjson.Field
+		UpdatedAt   respjson.Field
+		UserID      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r Device) RawJSON() string { return r.JSON.raw }
+
+func (r *Device) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Device status. Allowed values are active, pending, disabled
+type DeviceStatus string
+
+const (
+	DeviceStatusPending  DeviceStatus = "pending"
+	DeviceStatusActive   DeviceStatus = "active"
+	DeviceStatusDisabled DeviceStatus = "disabled"
+)
+
+// The result of a device check-in, as described by the
+// `aidr-device-check-result` schema.
+type DeviceCheckResult struct {
+	// The access token of the device, only present once it is active.
+	AccessToken DeviceTokenInfo `json:"access_token"`
+	// The collector service config of the device.
+	Config ServiceConfig `json:"config"`
+	Device Device        `json:"device"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		AccessToken respjson.Field
+		Config      respjson.Field
+		Device      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r DeviceCheckResult) RawJSON() string { return r.JSON.raw }
+
+func (r *DeviceCheckResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// An access token of a device, as described by the `aidr-device-token-info`
+// schema.
+type DeviceTokenInfo struct {
+	// Timestamp when the record when token is created (RFC 3339 format)
+	CreatedAt time.Time `json:"created_at" format:"date-time"`
+	// The lifetime in seconds of the access token.
+	ExpiresIn int64 `json:"expires_in"`
+	// The access token issued for given device.
+	Token string `json:"token"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CreatedAt   respjson.Field
+		ExpiresIn   respjson.Field
+		Token       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r DeviceTokenInfo) RawJSON() string { return r.JSON.raw }
+
+func (r *DeviceTokenInfo) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Lifetime returns the lifetime of the access token, or zero if the API did not
+// return one.
+func (r DeviceTokenInfo) Lifetime() time.Duration {
+	return time.Duration(r.ExpiresIn) * time.Second
+}
+
+// A page of devices, as described by the `aidr-device-search-result` schema.
+type DeviceSearchResult struct {
+	// Pagination count of returned records
+	Count   int64    `json:"count"`
+	Devices []Device `json:"devices"`
+	// Pagination last cursor
+	Last string `json:"last"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count       respjson.Field
+		Devices     respjson.Field
+		Last        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r DeviceSearchResult) RawJSON() string { return r.JSON.raw }
+
+func (r *DeviceSearchResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A device definition, as described by the `aidr-device` schema.
+//
+// The property ID is required.
+type DeviceParams struct {
+	// client generated unique ID.
+	ID string `json:"id,required"`
+	// Last observed IP address for this device.
+	LastUsedIP param.Opt[string] `json:"last_used_ip,omitzero"`
+	// Device name
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Owning user identifier.
+	UserID param.Opt[string] `json:"user_id,omitzero"`
+	// Arbitrary device metadata.
+	Metadata map[string]any `json:"metadata,omitzero"`
+	// Device status. Allowed values are active, pending, disabled
+	//
+	// Any of "pending", "active", "disabled".
+	Status DeviceStatus `json:"status,omitzero"`
+	paramObj
+}
+
+func (r DeviceParams) MarshalJSON() (data []byte, err error) {
+	type shadow DeviceParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *DeviceParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Filters for listing devices, as described by the `aidr-device-search` schema.
+type DeviceSearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]         `json:"size,omitzero"`
+	Filter DeviceSearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order DeviceSearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "name", "created_at", "updated_at".
+	OrderBy DeviceSearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r DeviceSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow DeviceSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *DeviceSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type DeviceSearchParamsFilter struct {
+	// Only records where created_at equals this value.
+	CreatedAt param.Opt[time.Time] `json:"created_at,omitzero" format:"date-time"`
+	// Only records where created_at is greater than this value.
+	CreatedAtGt param.Opt[time.Time] `json:"created_at__gt,omitzero" format:"date-time"`
+	// Only records where created_at is greater than or equal to this value.
+	CreatedAtGte param.Opt[time.Time] `json:"created_at__gte,omitzero" format:"date-time"`
+	// Only records where created_at is less than this value.
+	CreatedAtLt param.Opt[time.Time] `json:"created_at__lt,omitzero" format:"date-time"`
+	// Only records where created_at is less than or equal to this value.
+	CreatedAtLte param.Opt[time.Time] `json:"created_at__lte,omitzero" format:"date-time"`
+	// Only records where id is equal to the value
+	ID param.Opt[string] `json:"id,omitzero"`
+	// Only records where name is equal to the value
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Only records where updated_at equals this value.
+	UpdatedAt param.Opt[time.Time] `json:"updated_at,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than this value.
+	UpdatedAtGt param.Opt[time.Time] `json:"updated_at__gt,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than or equal to this value.
+	UpdatedAtGte param.Opt[time.Time] `json:"updated_at__gte,omitzero" format:"date-time"`
+	// Only records where updated_at is less than this value.
+	UpdatedAtLt param.Opt[time.Time] `json:"updated_at__lt,omitzero" format:"date-time"`
+	// Only records where updated_at is less than or equal to this value.
+	UpdatedAtLte param.Opt[time.Time] `json:"updated_at__lte,omitzero" format:"date-time"`
+	// Only records where id includes each substring.
+	IDContains []string `json:"id__contains,omitzero"`
+	// Only records where id equals one of the provided substrings.
+	IDIn []string `json:"id__in,omitzero"`
+	// Only records where name includes each substring.
+	NameContains []string `json:"name__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	NameIn []string `json:"name__in,omitzero"`
+	// Only records where status is equal to the value
+	//
+	// Any of "pending", "active", "disabled".
+	Status DeviceStatus `json:"status,omitzero"`
+	// Only records where status includes each substring.
+	StatusContains []string `json:"status__contains,omitzero"`
+	// Only records where status equals one of the provided values.
+	StatusIn []DeviceStatus `json:"status__in,omitzero"`
+	paramObj
+}
+
+func (r DeviceSearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow DeviceSearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *DeviceSearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type DeviceSearchParamsOrder string
+
+const (
+	DeviceSearchParamsOrderAsc  DeviceSearchParamsOrder = "asc"
+	DeviceSearchParamsOrderDesc DeviceSearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type DeviceSearchParamsOrderBy string
+
+const (
+	DeviceSearchParamsOrderByName      DeviceSearchParamsOrderBy = "name"
+	DeviceSearchParamsOrderByCreatedAt DeviceSearchParamsOrderBy = "created_at"
+	DeviceSearchParamsOrderByUpdatedAt DeviceSearchParamsOrderBy = "updated_at"
+)
@@ -0,0 +1,142 @@
+package aidr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+	"github.com/crowdstrike/aidr-go/option"
+)
+
+var _ option.TokenProvider = (*aidr.DeviceSession)(nil)
+
+func TestDeviceParams(t *testing.T) {
+	body := marshalObject(t, aidr.DeviceParams{ID: "dev_1", Status: aidr.DeviceStatusActive})
+	if body["id"] != "dev_1" || body["status"] != "active" || len(body) != 2 {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	body = marshalObject(t, aidr.DeviceSearchParams{
+		Filter: aidr.DeviceSearchParamsFilter{StatusIn: []aidr.DeviceStatus{aidr.DeviceStatusActive, aidr.DeviceStatusPending}},
+		Last:   aidr.String("cursor"),
+	})
+	if body["filter"].(map[string]any)["status__in"].([]any)[1] != "pending" || body["last"] != "cursor" {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestDeviceCheckResult(t *testing.T) {
+	var res aidr.DeviceCheckResult
+	err := json.Unmarshal([]byte(`{
+		"device": {"id": "dev_1", "status": "active", "last_used_ip": "10.0.0.1", "created_at": "2025-01-02T03:04:05Z"},
+		"config": {"id": "sc_1", "name": "Browser"},
+		"access_token": {"token": "device-token", "expires_in": 3600}
+	}`), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Device.Status != aidr.DeviceStatusActive || res.Device.LastUsedIP != "10.0.0.1" || res.Config.Name != "Browser" {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if res.AccessToken.Token != "device-token" || res.AccessToken.Lifetime() != time.Hour {
+		t.Errorf("unexpected access token %+v", res.AccessToken)
+	}
+}
+
+func TestDeviceSession(t *testing.T) {
+	checkIns := 0
+	status := "pending"
+	session := aidr.NewDeviceSession(func(ctx context.Context) (*aidr.DeviceCheckResult, error) {
+		checkIns++
+		result := fmt.Sprintf(`{"device": {"id": "dev_1", "status": %q}}`, status)
+		if status == "active" {
+			result = fmt.Sprintf(`{"device": {"id": "dev_1", "status": "active"}, "access_token": {"token": "device-%d", "expires_in": 3600}}`, checkIns)
+		}
+		var res aidr.DeviceCheckResult
+		err := json.Unmarshal([]byte(result), &res)
+		return &res, err
+	}, aidr.DeviceSessionOptions{})
+
+	if _, err := session.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "pending") {
+		t.Fatalf("expected an error for a pending device, received %v", err)
+	}
+
+	status = "active"
+	var seen []string
+	device := aidr.NewClient(
+		option.WithBaseURLTemplate("http://localhost/{SERVICE_NAME}"),
+		option.WithTokenProvider(session),
+		option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+			seen = append(seen, req.Header.Get("Authorization"))
+			if len(seen) == 2 {
+				return jsonResponse(req, http.StatusUnauthorized, `{"status":"Unauthorized"}`), nil
+			}
+			return jsonResponse(req, http.StatusOK, `{"status":"Success","result":{}}`), nil
+		}),
+	)
+	for range 2 {
+		if _, err := device.AIGuard.GuardChatCompletions(context.Background(), guardParams()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The token is reused until the API rejects it.
+	want := "Bearer device-2,Bearer device-2,Bearer device-3"
+	if strings.Join(seen, ",") != want || checkIns != 3 {
+		t.Errorf("expected %s after 3 check-ins, received %s after %d", want, strings.Join(seen, ","), checkIns)
+	}
+	if last := session.Last(); last == nil || last.AccessToken.Token != "device-3" {
+		t.Errorf("unexpected last check-in %+v", last)
+	}
+}
+
+func TestDeviceSessionTokenWithoutExpiration(t *testing.T) {
+	var checkIns atomic.Int32
+	release := make(chan struct{})
+	session := aidr.NewDeviceSession(func(ctx context.Context) (*aidr.DeviceCheckResult, error) {
+		if checkIns.Add(1) == 1 {
+			<-release
+		}
+		var res aidr.DeviceCheckResult
+		err := json.Unmarshal([]byte(`{"device": {"id": "dev_1", "status": "active"}, "access_token": {"token": "forever"}}`), &res)
+		return &res, err
+	}, aidr.DeviceSessionOptions{CheckInInterval: time.Hour})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if token, err := session.Token(context.Background()); err != nil || token != "forever" {
+			t.Errorf("unexpected token %q, %v", token, err)
+		}
+	}()
+	for checkIns.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// The lock is not held while checking in.
+	if last := session.Last(); last != nil {
+		t.Errorf("expected no check-in yet, got %+v", last)
+	}
+	close(release)
+	<-done
+
+	for range 5 {
+		if token, err := session.Token(context.Background()); err != nil || token != "forever" {
+			t.Fatalf("unexpected token %q, %v", token, err)
+		}
+	}
+	if n := checkIns.Load(); n != 1 {
+		t.Errorf("expected a token without expiration to be reused, checked in %d times", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	session.Run(ctx)
+	if n := checkIns.Load(); n != 2 {
+		t.Errorf("expected Run to check in once and wait, checked in %d times", n-1)
+	}
+}
@@ -0,0 +1,198 @@
+package aidr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeviceSessionOptions configures a [DeviceSession].
+type DeviceSessionOptions struct {
+	// RefreshBefore is how long before the access token expires the device
+	// checks in again. Defaults to 1 minute, and is capped at half the lifetime
+	// of the token.
+	RefreshBefore time.Duration
+	// RetryInterval is the delay before checking in again after a failed
+	// check-in in [DeviceSession.Run]. Defaults to 10 seconds.
+	RetryInterval time.Duration
+	// CheckInInterval is the delay between two check-ins in [DeviceSession.Run]
+	// when the access token does not expire, that is when the API returns no
+	// expires_in. Defaults to 5 minutes.
+	CheckInInterval time.Duration
+	// OnCheckIn, if set, is called after every check-in with its result or
+	// error.
+	OnCheckIn func(*DeviceCheckResult, error)
+}
+
+// DeviceCheckInFunc checks in a device and returns the result of the check-in.
+type DeviceCheckInFunc func(ctx context.Context) (*DeviceCheckResult, error)
+
+// DeviceSession keeps the access token of a device fresh by checking in again
+// before the token expires. It implements [option.TokenProvider], so that the
+// device token can be used to authenticate a client:
+//
+//	session := aidr.NewDeviceSession(checkIn, aidr.DeviceSessionOptions{})
+//	deviceClient := aidr.NewClient(option.WithTokenProvider(session))
+//
+// Check-ins are made by the given [DeviceCheckInFunc], as the spec doesn't
+// publish the route of the device API. It must not authenticate with the
+// session itself, as checking in would then require the token it is about to
+// fetch.
+//
+// A token returned without an expiration is used until it is invalidated.
+//
+// A DeviceSession is safe for concurrent use.
+type DeviceSession struct {
+	checkInFunc DeviceCheckInFunc
+	options     DeviceSessionOptions
+
+	mu    sync.Mutex
+	last  *DeviceCheckResult
+	token string
+	// refreshAt is zero when the token does not expire.
+	refreshAt time.Time
+	// pending is closed when the check-in in progress for Token completes.
+	pending chan struct{}
+}
+
+// NewDeviceSession returns a [DeviceSession] checking in with checkIn. No
+// check-in is made until a token is needed or [DeviceSession.Run] is called.
+func NewDeviceSession(checkIn DeviceCheckInFunc, options DeviceSessionOptions) *DeviceSession {
+	if options.RefreshBefore <= 0 {
+		options.RefreshBefore = time.Minute
+	}
+	if options.RetryInterval <= 0 {
+		options.RetryInterval = 10 * time.Second
+	}
+	if options.CheckInInterval <= 0 {
+		options.CheckInInterval = 5 * time.Minute
+	}
+	return &DeviceSession{checkInFunc: checkIn, options: options}
+}
+
+// Token returns the access token of the device, checking in first if there is
+// none yet or if it is about to expire. It returns an error if the device is not
+// active. Concurrent calls share a single check-in.
+func (s *DeviceSession) Token(ctx context.Context) (string, error) {
+	for {
+		s.mu.Lock()
+		if s.token != "" && (s.refreshAt.IsZero() || time.Now().Before(s.refreshAt)) {
+			token := s.token
+			s.mu.Unlock()
+			return token, nil
+		}
+		if pending := s.pending; pending != nil {
+			s.mu.Unlock()
+			select {
+			case <-pending:
+				continue
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+		pending := make(chan struct{})
+		s.pending = pending
+		s.mu.Unlock()
+
+		_, err := s.checkIn(ctx)
+
+		s.mu.Lock()
+		s.pending = nil
+		token := s.token
+		s.mu.Unlock()
+		close(pending)
+		if err != nil {
+			return "", err
+		}
+		return token, nil
+	}
+}
+
+// Invalidate discards token if it is the current one, so that the next call to
+// [DeviceSession.Token] checks in again.
+func (s *DeviceSession) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// CheckIn checks in the device now, updating the access token.
+func (s *DeviceSession) CheckIn(ctx context.Context) (*DeviceCheckResult, error) {
+	return s.checkIn(ctx)
+}
+
+// Last returns the result of the latest successful check-in, or nil if there is
+// none yet.
+func (s *DeviceSession) Last() *DeviceCheckResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.last
+}
+
+// Run checks in the device right away and then again before every access token
+// expires, until ctx is done. Failed check-ins are retried after
+// [DeviceSessionOptions.RetryInterval], and tokens that do not expire are
+// refreshed every [DeviceSessionOptions.CheckInInterval]. Run always returns
+// ctx.Err().
+func (s *DeviceSession) Run(ctx context.Context) error {
+	for {
+		_, err := s.checkIn(ctx)
+		s.mu.Lock()
+		wait := s.options.CheckInInterval
+		if !s.refreshAt.IsZero() {
+			wait = time.Until(s.refreshAt)
+		}
+		s.mu.Unlock()
+
+		if err != nil || wait <= 0 {
+			wait = s.options.RetryInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// checkIn must be called without s.mu held; the lock is only taken to store the
+// result.
+func (s *DeviceSession) checkIn(ctx context.Context) (res *DeviceCheckResult, err error) {
+	defer func() {
+		if s.options.OnCheckIn != nil {
+			s.options.OnCheckIn(res, err)
+		}
+	}()
+
+	res, err = s.checkInFunc(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = res
+	if res.Device.Status != "" && res.Device.Status != DeviceStatusActive {
+		s.token = ""
+		return res, fmt.Errorf("aidr: device %s is %s", res.Device.ID, res.Device.Status)
+	}
+	if res.AccessToken.Token == "" {
+		s.token = ""
+		return res, fmt.Errorf("aidr: check-in of device %s returned no access token", res.Device.ID)
+	}
+
+	s.token = res.AccessToken.Token
+	s.refreshAt = time.Time{}
+	if lifetime := res.AccessToken.Lifetime(); lifetime > 0 {
+		s.refreshAt = time.Now().Add(lifetime - min(s.options.RefreshBefore, lifetime/2))
+	}
+	return res, nil
+}
@@ -0,0 +1,209 @@
+package aidr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A field alias, as described by the `aidr-field-alias-result` schema. The spec
+// publishes no routes for field aliases, so the SDK has no service for them.
+type FieldAlias struct {
+	// Alternate display name or alias
+	FieldAlias string `json:"field_alias,required"`
+	// Unique name for the field
+	FieldName string `json:"field_name,required"`
+	// Field type
+	FieldType string `json:"field_type,required"`
+	// Timestamp when the record was created (RFC 3339 format)
+	CreatedAt time.Time `json:"created_at,required" format:"date-time"`
+	// Timestamp when the record was last updated (RFC 3339 format)
+	UpdatedAt time.Time `json:"updated_at,required" format:"date-time"`
+	// Array of tag strings
+	FieldTags []string `json:"field_tags"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		FieldAlias  respjson.Field
+		FieldName   respjson.Field
+		FieldType   respjson.Field
+		CreatedAt   respjson.Field
+		UpdatedAt   respjson.Field
+		FieldTags   respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r FieldAlias) RawJSON() string { return r.JSON.raw }
+
+func (r *FieldAlias) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A page of field aliases, as described by the
+// `aidr-field-alias-search-result` schema.
+type FieldAliasSearchResult struct {
+	// Pagination limit
+	Count int64        `json:"count"`
+	Items []FieldAlias `json:"items"`
+	// Pagination last count
+	Last string `json:"last"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count       respjson.Field
+		Items       respjson.Field
+		Last        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r FieldAliasSearchResult) RawJSON() string { return r.JSON.raw }
+
+func (r *FieldAliasSearchResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A field alias definition, as described by the `aidr-field-alias` schema.
+// Marshaling fails if FieldTags holds duplicates, as the tags must be unique.
+//
+// The properties FieldAlias, FieldName, FieldType are required.
+type FieldAliasParams struct {
+	// Alternate display name or alias
+	FieldAlias string `json:"field_alias,required"`
+	// Unique name for the field
+	FieldName string `json:"field_name,required"`
+	// Field type
+	FieldType string `json:"field_type,required"`
+	// Array of unique tag strings
+	FieldTags []string `json:"field_tags,omitzero"`
+	paramObj
+}
+
+func (r FieldAliasParams) MarshalJSON() (data []byte, err error) {
+	if err := checkFieldTags(r.FieldTags); err != nil {
+		return nil, err
+	}
+	type shadow FieldAliasParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *FieldAliasParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Filters for listing field aliases, as described by the
+// `aidr-field-alias-search` schema.
+type FieldAliasSearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]             `json:"size,omitzero"`
+	Filter FieldAliasSearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order FieldAliasSearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "field_name", "field_type", "created_at", "updated_at",
+	// "published_at", "field_alias".
+	OrderBy FieldAliasSearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r FieldAliasSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow FieldAliasSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *FieldAliasSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type FieldAliasSearchParamsFilter struct {
+	// Only records where created_at equals this value.
+	CreatedAt param.Opt[time.Time] `json:"created_at,omitzero" format:"date-time"`
+	// Only records where created_at is greater than this value.
+	CreatedAtGt param.Opt[time.Time] `json:"created_at__gt,omitzero" format:"date-time"`
+	// Only records where created_at is greater than or equal to this value.
+	CreatedAtGte param.Opt[time.Time] `json:"created_at__gte,omitzero" format:"date-time"`
+	// Only records where created_at is less than this value.
+	CreatedAtLt param.Opt[time.Time] `json:"created_at__lt,omitzero" format:"date-time"`
+	// Only records where created_at is less than or equal to this value.
+	CreatedAtLte param.Opt[time.Time] `json:"created_at__lte,omitzero" format:"date-time"`
+	// Only records where field alias equals this value.
+	FieldAlias param.Opt[string] `json:"field_alias,omitzero"`
+	// Only records where field name is equal to the value
+	FieldName param.Opt[string] `json:"field_name,omitzero"`
+	// Only records where field type equals this value.
+	FieldType param.Opt[string] `json:"field_type,omitzero"`
+	// Only records where updated_at equals this value.
+	UpdatedAt param.Opt[time.Time] `json:"updated_at,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than this value.
+	UpdatedAtGt param.Opt[time.Time] `json:"updated_at__gt,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than or equal to this value.
+	UpdatedAtGte param.Opt[time.Time] `json:"updated_at__gte,omitzero" format:"date-time"`
+	// Only records where updated_at is less than this value.
+	UpdatedAtLt param.Opt[time.Time] `json:"updated_at__lt,omitzero" format:"date-time"`
+	// Only records where updated_at is less than or equal to this value.
+	UpdatedAtLte param.Opt[time.Time] `json:"updated_at__lte,omitzero" format:"date-time"`
+	// Only records where field alias includes each substring.
+	FieldAliasContains []string `json:"field_alias__contains,omitzero"`
+	// Only records where field alias equals one of the provided substrings.
+	FieldAliasIn []string `json:"field_alias__in,omitzero"`
+	// Only records where field name includes each substring.
+	FieldNameContains []string `json:"field_name__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	FieldNameIn []string `json:"field_name__in,omitzero"`
+	// Only records where field type includes each substring.
+	FieldTypeContains []string `json:"field_type__contains,omitzero"`
+	// Only records where field type equals one of the provided substrings.
+	FieldTypeIn []string `json:"field_type__in,omitzero"`
+	paramObj
+}
+
+func (r FieldAliasSearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow FieldAliasSearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *FieldAliasSearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type FieldAliasSearchParamsOrder string
+
+const (
+	FieldAliasSearchParamsOrderAsc  FieldAliasSearchParamsOrder = "asc"
+	FieldAliasSearchParamsOrderDesc FieldAliasSearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type FieldAliasSearchParamsOrderBy string
+
+const (
+	FieldAliasSearchParamsOrderByFieldName   FieldAliasSearchParamsOrderBy = "field_name"
+	FieldAliasSearchParamsOrderByFieldType   FieldAliasSearchParamsOrderBy = "field_type"
+	FieldAliasSearchParamsOrderByCreatedAt   FieldAliasSearchParamsOrderBy = "created_at"
+	FieldAliasSearchParamsOrderByUpdatedAt   FieldAliasSearchParamsOrderBy = "updated_at"
+	FieldAliasSearchParamsOrderByPublishedAt FieldAliasSearchParamsOrderBy = "published_at"
+	FieldAliasSearchParamsOrderByFieldAlias  FieldAliasSearchParamsOrderBy = "field_alias"
+)
+
+func checkFieldTags(tags []string) error {
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if seen[tag] {
+			return fmt.Errorf("aidr: duplicate field tag %q", tag)
+		}
+		seen[tag] = true
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestFieldAlias(t *testing.T) {
+	body := marshalObject(t, aidr.FieldAliasParams{
+		FieldName:  "app_id",
+		FieldType:  "string",
+		FieldAlias: "Application",
+		FieldTags:  []string{"app", "core"},
+	})
+	if body["field_name"] != "app_id" || body["field_alias"] != "Application" || len(body["field_tags"].([]any)) != 2 {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var alias aidr.FieldAlias
+	err := json.Unmarshal([]byte(`{
+		"field_name": "app_id",
+		"field_type": "string",
+		"field_alias": "Application",
+		"field_tags": ["app", "core"]
+	}`), &alias)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.FieldAlias != "Application" || len(alias.FieldTags) != 2 {
+		t.Errorf("unexpected alias %+v", alias)
+	}
+}
+
+func TestFieldAliasRejectsDuplicateTags(t *testing.T) {
+	_, err := json.Marshal(aidr.FieldAliasParams{
+		FieldName:  "app_id",
+		FieldType:  "string",
+		FieldAlias: "Application",
+		FieldTags:  []string{"app", "core", "app"},
+	})
+	if err == nil {
+		t.Error("expected an error for duplicate field tags")
+	}
+}
+
+func TestFieldAliasSearch(t *testing.T) {
+	body := marshalObject(t, aidr.FieldAliasSearchParams{
+		Filter: aidr.FieldAliasSearchParamsFilter{FieldTypeIn: []string{"string"}},
+	})
+	if body["filter"].(map[string]any)["field_type__in"].([]any)[0] != "string" {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var res aidr.FieldAliasSearchResult
+	if err := json.Unmarshal([]byte(`{"count": 1, "items": [{"field_name": "model", "field_type": "string", "field_alias": "Model"}]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 1 || res.Items[0].FieldName != "model" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
@@ -0,0 +1,201 @@
+package aidr
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+	"github.com/tidwall/gjson"
+)
+
+// Finding is one detection of a detector, in a shape common to all detectors. See
+// [AIGuardGuardChatCompletionsResponseResultDetectors.Findings].
+type Finding struct {
+	// Detector is the key of the detector in the result, such as "topic" or
+	// "confidential_and_pii_entity".
+	Detector string
+	// Action is the action taken on the finding, or by its detector when the
+	// finding has none of its own.
+	Action FindingAction
+	// EntityType is the type of the detected entity, such as "EMAIL_ADDRESS", for
+	// the detectors that report entities.
+	EntityType string
+	// Value is the detected entity, competitor, language, topic, code language or
+	// analyzer.
+	Value string
+	// StartPos is the position of Value in the input, or -1 when the detector does
+	// not report one.
+	StartPos int64
+	// Confidence is the confidence of the detection, between 0 and 1, or 0 when the
+	// detector does not report one.
+	Confidence float64
+	// Raw is the JSON of the finding as received from the API.
+	Raw string
+}
+
+// The action taken by a detector. Actions are normalized to the past tense, so
+// "block" and "blocked" are both [FindingActionBlocked]. Actions unknown to the
+// SDK are kept as received.
+type FindingAction string
+
+const (
+	// No action was reported.
+	FindingActionNone     FindingAction = ""
+	FindingActionAllowed  FindingAction = "allowed"
+	FindingActionBlocked  FindingAction = "blocked"
+	FindingActionRedacted FindingAction = "redacted"
+	FindingActionDefanged FindingAction = "defanged"
+	FindingActionReported FindingAction = "reported"
+)
+
+// ParseFindingAction returns the [FindingAction] of an action string of the API.
+func ParseFindingAction(action string) FindingAction {
+	switch a := strings.ToLower(strings.TrimSpace(action)); a {
+	case "allow":
+		return FindingActionAllowed
+	case "block":
+		return FindingActionBlocked
+	case "redact":
+		return FindingActionRedacted
+	case "defang":
+		return FindingActionDefanged
+	case "report":
+		return FindingActionReported
+	default:
+		return FindingAction(a)
+	}
+}
+
+var knownDetectors = []string{
+	"code",
+	"competitors",
+	"confidential_and_pii_entity",
+	"custom_entity",
+	"language",
+	"malicious_entity",
+	"malicious_prompt",
+	"secret_and_key_entity",
+	"topic",
+}
+
+// Findings returns the findings of the detectors that detected something, in the
+// order of the fields of the struct, followed by the findings of the detectors
+// unknown to the SDK, sorted by key. The findings of unknown detectors are read
+// from JSON.ExtraFields: one finding per item of "data.entities" when present,
+// and a single finding otherwise.
+func (r AIGuardGuardChatCompletionsResponseResultDetectors) Findings() iter.Seq[Finding] {
+	return func(yield func(Finding) bool) {
+		for _, f := range r.knownFindings() {
+			if !yield(f) {
+				return
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(r.JSON.ExtraFields)) {
+			if slices.Contains(knownDetectors, key) {
+				continue
+			}
+			for _, f := range unknownDetectorFindings(key, r.JSON.ExtraFields[key].Raw()) {
+				if !yield(f) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r AIGuardGuardChatCompletionsResponseResultDetectors) knownFindings() []Finding {
+	var res []Finding
+	if d := r.Code; d.Detected {
+		res = append(res, Finding{Detector: "code", Action: ParseFindingAction(d.Data.Action), Value: d.Data.Language, StartPos: -1, Raw: d.Data.RawJSON()})
+	}
+	if d := r.Competitors; d.Detected {
+		action := ParseFindingAction(d.Data.Action)
+		for _, entity := range d.Data.Entities {
+			res = append(res, Finding{Detector: "competitors", Action: action, Value: entity, StartPos: -1, Raw: d.Data.RawJSON()})
+		}
+	}
+	if d := r.ConfidentialAndPiiEntity; d.Detected {
+		for _, e := range d.Data.Entities {
+			res = append(res, Finding{Detector: "confidential_and_pii_entity", Action: ParseFindingAction(e.Action), EntityType: e.Type, Value: e.Value, StartPos: startPos(e.JSON.StartPos, e.StartPos), Raw: e.RawJSON()})
+		}
+	}
+	if d := r.CustomEntity; d.Detected {
+		for _, e := range d.Data.Entities {
+			res = append(res, Finding{Detector: "custom_entity", Action: ParseFindingAction(e.Action), EntityType: e.Type, Value: e.Value, StartPos: startPos(e.JSON.StartPos, e.StartPos), Raw: e.RawJSON()})
+		}
+	}
+	if d := r.Language; d.Detected {
+		res = append(res, Finding{Detector: "language", Action: ParseFindingAction(d.Data.Action), Value: d.Data.Language, StartPos: -1, Raw: d.Data.RawJSON()})
+	}
+	if d := r.MaliciousEntity; d.Detected {
+		// Malicious entities carry no action; use the one of the detector, if any.
+		action := ParseFindingAction(gjson.Get(d.Data.RawJSON(), "action").String())
+		for _, e := range d.Data.Entities {
+			res = append(res, Finding{Detector: "malicious_entity", Action: action, EntityType: e.Type, Value: e.Value, StartPos: startPos(e.JSON.StartPos, e.StartPos), Raw: e.RawJSON()})
+		}
+	}
+	if d := r.MaliciousPrompt; d.Detected {
+		action := ParseFindingAction(d.Data.Action)
+		for _, a := range d.Data.AnalyzerResponses {
+			res = append(res, Finding{Detector: "malicious_prompt", Action: action, Value: a.Analyzer, StartPos: -1, Confidence: a.Confidence, Raw: a.RawJSON()})
+		}
+		if len(d.Data.AnalyzerResponses) == 0 {
+			res = append(res, Finding{Detector: "malicious_prompt", Action: action, StartPos: -1, Raw: d.Data.RawJSON()})
+		}
+	}
+	if d := r.SecretAndKeyEntity; d.Detected {
+		for _, e := range d.Data.Entities {
+			res = append(res, Finding{Detector: "secret_and_key_entity", Action: ParseFindingAction(e.Action), EntityType: e.Type, Value: e.Value, StartPos: startPos(e.JSON.StartPos, e.StartPos), Raw: e.RawJSON()})
+		}
+	}
+	if d := r.Topic; d.Detected {
+		action := ParseFindingAction(d.Data.Action)
+		for _, t := range d.Data.Topics {
+			res = append(res, Finding{Detector: "topic", Action: action, Value: t.Topic, StartPos: -1, Confidence: t.Confidence, Raw: t.RawJSON()})
+		}
+	}
+	return res
+}
+
+func startPos(field respjson.Field, pos int64) int64 {
+	if !field.Valid() {
+		return -1
+	}
+	return pos
+}
+
+func unknownDetectorFindings(key string, raw string) []Finding {
+	detector := gjson.Parse(raw)
+	if !detector.IsObject() || !detector.Get("detected").Bool() {
+		return nil
+	}
+	data := detector.Get("data")
+	action := ParseFindingAction(data.Get("action").String())
+	entities := data.Get("entities")
+	if !entities.IsArray() {
+		return []Finding{{Detector: key, Action: action, StartPos: -1, Raw: data.Raw}}
+	}
+
+	var res []Finding
+	for _, e := range entities.Array() {
+		f := Finding{Detector: key, Action: action, StartPos: -1, Raw: e.Raw}
+		if !e.IsObject() {
+			f.Value = e.String()
+			res = append(res, f)
+			continue
+		}
+		if a := e.Get("action"); a.Exists() {
+			f.Action = ParseFindingAction(a.String())
+		}
+		if pos := e.Get("start_pos"); pos.Exists() {
+			f.StartPos = pos.Int()
+		}
+		f.EntityType = e.Get("type").String()
+		f.Value = e.Get("value").String()
+		f.Confidence = e.Get("confidence").Float()
+		res = append(res, f)
+	}
+	return res
+}
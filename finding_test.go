@@ -0,0 +1,81 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestDetectorsFindings(t *testing.T) {
+	var detectors aidr.AIGuardGuardChatCompletionsResponseResultDetectors
+	err := json.Unmarshal([]byte(`{
+		"code": {"detected": false, "data": {"action": "blocked", "language": "python"}},
+		"competitors": {"detected": true, "data": {"action": "report", "entities": ["Acme"]}},
+		"confidential_and_pii_entity": {"detected": true, "data": {"entities": [
+			{"action": "redacted", "type": "EMAIL_ADDRESS", "value": "a@b.c", "start_pos": 0},
+			{"action": "redacted", "type": "PHONE_NUMBER", "value": "555-0100"}
+		]}},
+		"language": {"detected": true, "data": {"action": "allowed", "language": "en"}},
+		"malicious_entity": {"detected": true, "data": {"action": "defanged", "entities": [{"type": "URL", "value": "http://evil", "start_pos": 12}]}},
+		"malicious_prompt": {"detected": true, "data": {"action": "blocked", "analyzer_responses": [{"analyzer": "PA4002", "confidence": 0.97}]}},
+		"topic": {"detected": true, "data": {"action": "Block", "topics": [{"topic": "politics", "confidence": 0.8}]}},
+		"zz_future": {"detected": true, "data": {"action": "reported"}},
+		"image": {"detected": true, "data": {"action": "blocked", "entities": [{"type": "NSFW", "value": "img-1", "confidence": 0.9}, "plain"]}},
+		"disabled": {"detected": false, "data": {"entities": [{"type": "X"}]}}
+	}`), &detectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		Detector   string
+		Action     aidr.FindingAction
+		EntityType string
+		Value      string
+		StartPos   int64
+		Confidence float64
+	}
+	var got []summary
+	for f := range detectors.Findings() {
+		got = append(got, summary{f.Detector, f.Action, f.EntityType, f.Value, f.StartPos, f.Confidence})
+	}
+	want := []summary{
+		{"competitors", aidr.FindingActionReported, "", "Acme", -1, 0},
+		{"confidential_and_pii_entity", aidr.FindingActionRedacted, "EMAIL_ADDRESS", "a@b.c", 0, 0},
+		{"confidential_and_pii_entity", aidr.FindingActionRedacted, "PHONE_NUMBER", "555-0100", -1, 0},
+		{"language", aidr.FindingActionAllowed, "", "en", -1, 0},
+		{"malicious_entity", aidr.FindingActionDefanged, "URL", "http://evil", 12, 0},
+		{"malicious_prompt", aidr.FindingActionBlocked, "", "PA4002", -1, 0.97},
+		{"topic", aidr.FindingActionBlocked, "", "politics", -1, 0.8},
+		{"image", aidr.FindingActionBlocked, "NSFW", "img-1", -1, 0.9},
+		{"image", aidr.FindingActionBlocked, "", "plain", -1, 0},
+		{"zz_future", aidr.FindingActionReported, "", "", -1, 0},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected findings\n got: %+v\nwant: %+v", got, want)
+	}
+
+	for f := range detectors.Findings() {
+		if f.Detector != "competitors" {
+			t.Errorf("expected iteration to stop after the first finding, got %s", f.Detector)
+		}
+		break
+	}
+}
+
+func TestParseFindingAction(t *testing.T) {
+	tests := map[string]aidr.FindingAction{
+		"":           aidr.FindingActionNone,
+		"block":      aidr.FindingActionBlocked,
+		"Blocked":    aidr.FindingActionBlocked,
+		"redact":     aidr.FindingActionRedacted,
+		"quarantine": aidr.FindingAction("quarantine"),
+	}
+	for action, want := range tests {
+		if got := aidr.ParseFindingAction(action); got != want {
+			t.Errorf("ParseFindingAction(%q) = %q, want %q", action, got, want)
+		}
+	}
+}
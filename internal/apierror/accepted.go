@@ -0,0 +1,77 @@
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// AcceptedError is returned when the API answers with HTTP 202 Accepted, meaning
+// the request is still being processed and no result is available yet. It is
+// never a verdict: the final result must be retrieved later using RequestID, for
+// example with AIGuardService.GetAsyncRequest.
+type AcceptedError struct {
+	// The ID of the request that is still in progress.
+	RequestID    string              `json:"request_id"`
+	RequestTime  time.Time           `json:"request_time" format:"date-time"`
+	ResponseTime time.Time           `json:"response_time" format:"date-time"`
+	Result       AcceptedErrorResult `json:"result"`
+	// Any of "Accepted".
+	Status  string `json:"status"`
+	Summary string `json:"summary"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		RequestID    respjson.Field
+		RequestTime  respjson.Field
+		ResponseTime respjson.Field
+		Result       respjson.Field
+		Status       respjson.Field
+		Summary      respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+	StatusCode int
+	Request    *http.Request
+	Response   *http.Response
+}
+
+// Returns the unmodified JSON received from the API
+func (r AcceptedError) RawJSON() string { return r.JSON.raw }
+
+func (r *AcceptedError) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r *AcceptedError) Error() string {
+	return fmt.Sprintf("%s %q: %d %s: request %s is still in progress", r.Request.Method, r.Request.URL, r.StatusCode, http.StatusText(r.StatusCode), r.RequestID)
+}
+
+// Location returns the URL at which the result of the request can be retrieved.
+func (r *AcceptedError) Location() string { return r.Result.Location }
+
+type AcceptedErrorResult struct {
+	// The URL at which the result of the request can be retrieved.
+	Location string `json:"location"`
+	// The number of times the result has been polled so far.
+	RetryCounter int64 `json:"retry_counter"`
+	// The number of minutes the result will be retained for.
+	TTLMins int64 `json:"ttl_mins"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Location     respjson.Field
+		RetryCounter respjson.Field
+		TTLMins      respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r AcceptedErrorResult) RawJSON() string { return r.JSON.raw }
+
+func (r *AcceptedErrorResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
@@ -3,20 +3,41 @@ package apierror
 import (
 	"fmt"
 	"net/http"
-	"net/http/httputil"
+	"time"
 
 	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/redact"
 	"github.com/crowdstrike/aidr-go/packages/respjson"
 )
 
 // Error represents an error that originates from the API, i.e. when a request is
 // made and the API returns a response with a HTTP status code. Other errors are
 // not wrapped by this SDK.
+//
+// Errors returned in the Pangea response envelope expose its request ID, status
+// and summary, and validation failures expose a typed list of
+// [ValidationError]. Use [errors.Is] with the sentinel errors of this package to
+// branch on the kind of failure.
 type Error struct {
+	// A unique identifier assigned to each request made to the API.
+	RequestID    string    `json:"request_id"`
+	RequestTime  time.Time `json:"request_time" format:"date-time"`
+	ResponseTime time.Time `json:"response_time" format:"date-time"`
+	// The status or outcome of the API request, e.g. "ValidationError".
+	Status string `json:"status"`
+	// A brief description of the error.
+	Summary string      `json:"summary"`
+	Result  ErrorResult `json:"result"`
 	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
 	JSON struct {
-		ExtraFields map[string]respjson.Field
-		raw         string
+		RequestID    respjson.Field
+		RequestTime  respjson.Field
+		ResponseTime respjson.Field
+		Status       respjson.Field
+		Summary      respjson.Field
+		Result       respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
 	} `json:"-"`
 	StatusCode int
 	Request    *http.Request
@@ -35,15 +56,35 @@ func (r *Error) Error() string {
 	return fmt.Sprintf("%s %q: %d %s %s", r.Request.Method, r.Request.URL, r.Response.StatusCode, http.StatusText(r.Response.StatusCode), r.JSON.raw)
 }
 
+// DumpRequest dumps the request with credentials such as the Authorization
+// header masked. If body is true, the body is rendered as its length and SHA-256
+// digest. Use [Error.DumpRequestRedacted] to choose how the body is rendered.
 func (r *Error) DumpRequest(body bool) []byte {
-	if r.Request.GetBody != nil {
-		r.Request.Body, _ = r.Request.GetBody()
-	}
-	out, _ := httputil.DumpRequestOut(r.Request, body)
-	return out
+	return r.DumpRequestRedacted(dumpMode(body))
 }
 
+// DumpResponse dumps the response with sensitive headers masked. If body is
+// true, the body is rendered as its length and SHA-256 digest. Use
+// [Error.DumpResponseRedacted] to choose how the body is rendered.
 func (r *Error) DumpResponse(body bool) []byte {
-	out, _ := httputil.DumpResponse(r.Response, body)
-	return out
+	return r.DumpResponseRedacted(dumpMode(body))
+}
+
+// DumpRequestRedacted is like DumpRequest, but renders the body according to
+// mode.
+func (r *Error) DumpRequestRedacted(mode redact.Mode) []byte {
+	return redact.DumpRequest(r.Request, mode)
+}
+
+// DumpResponseRedacted is like DumpResponse, but renders the body according to
+// mode.
+func (r *Error) DumpResponseRedacted(mode redact.Mode) []byte {
+	return redact.DumpResponse(r.Response, mode)
+}
+
+func dumpMode(body bool) redact.Mode {
+	if body {
+		return redact.Hash
+	}
+	return redact.Omit
 }
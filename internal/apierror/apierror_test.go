@@ -0,0 +1,103 @@
+package apierror_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go/internal/apierror"
+	"github.com/crowdstrike/aidr-go/packages/redact"
+)
+
+func TestValidationErrors(t *testing.T) {
+	raw := `{
+		"request_id": "prq_123",
+		"request_time": "2022-09-21T17:24:33.105Z",
+		"response_time": "2022-09-21T17:24:34.007Z",
+		"status": "ValidationError",
+		"summary": "There was 1 error(s) in the given payload. Please check the 'errors' field for details",
+		"result": {
+			"errors": [
+				{"code": "FieldRequired", "detail": "'guard_input' is a required property", "source": "/", "path": "/required"}
+			]
+		}
+	}`
+
+	aerr := apierror.Error{StatusCode: http.StatusBadRequest}
+	if err := aerr.UnmarshalJSON([]byte(raw)); err != nil {
+		t.Fatalf("didn't expect error %v", err)
+	}
+	if aerr.RequestID != "prq_123" || aerr.Status != "ValidationError" || aerr.Summary == "" {
+		t.Errorf("unexpected envelope %#v", aerr)
+	}
+	errs := aerr.ValidationErrors()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 validation error, received %d", len(errs))
+	}
+	if errs[0].Code != apierror.ValidationErrorCodeFieldRequired || errs[0].Source != "/" || errs[0].Path != "/required" {
+		t.Errorf("unexpected validation error %#v", errs[0])
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	tests := map[int]error{
+		http.StatusBadRequest:          apierror.ErrValidation,
+		http.StatusUnauthorized:        apierror.ErrAuthentication,
+		http.StatusForbidden:           apierror.ErrAuthentication,
+		http.StatusNotFound:            apierror.ErrNotFound,
+		http.StatusTooManyRequests:     apierror.ErrRateLimited,
+		http.StatusInternalServerError: apierror.ErrServer,
+		http.StatusBadGateway:          apierror.ErrServer,
+	}
+	sentinels := []error{apierror.ErrValidation, apierror.ErrAuthentication, apierror.ErrNotFound, apierror.ErrRateLimited, apierror.ErrServer}
+
+	for statusCode, want := range tests {
+		var err error = &apierror.Error{StatusCode: statusCode}
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == want) {
+				t.Errorf("status %d: errors.Is(%v) = %t", statusCode, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestDumpRedacted(t *testing.T) {
+	body := `{"guard_input":{"messages":[{"role":"user","content":"my secret prompt"}]}}`
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example.com/aiguard/v1/guard_chat_completions", nil)
+	req.Header.Set("Authorization", "Bearer my-token")
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(body)), nil }
+	res := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"status":"ValidationError"}`)),
+	}
+	aerr := &apierror.Error{Request: req, Response: res, StatusCode: res.StatusCode}
+
+	dump := string(aerr.DumpRequestRedacted(redact.Hash))
+	if strings.Contains(dump, "my-token") || !strings.Contains(dump, redact.Mask) {
+		t.Errorf("expected masked authorization header in %q", dump)
+	}
+	if strings.Contains(dump, "my secret prompt") || !strings.Contains(dump, "sha256:") {
+		t.Errorf("expected hashed body in %q", dump)
+	}
+	if req.Header.Get("Authorization") != "Bearer my-token" {
+		t.Errorf("expected original request to be untouched")
+	}
+
+	dump = string(aerr.DumpResponseRedacted(redact.Full))
+	if !strings.Contains(dump, "ValidationError") {
+		t.Errorf("expected response body in %q", dump)
+	}
+	if dump = string(aerr.DumpResponseRedacted(redact.Omit)); strings.Contains(dump, "ValidationError") {
+		t.Errorf("expected omitted response body in %q", dump)
+	}
+
+	dump = string(aerr.DumpRequest(true))
+	if strings.Contains(dump, "my-token") || strings.Contains(dump, "my secret prompt") {
+		t.Errorf("expected DumpRequest to redact by default, received %q", dump)
+	}
+}
@@ -0,0 +1,179 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// Sentinel errors that an [*Error] matches with [errors.Is], depending on its
+// status code.
+var (
+	// ErrAuthentication matches HTTP 401 and 403 responses.
+	ErrAuthentication = errors.New("aidr: authentication failed")
+	// ErrRateLimited matches HTTP 429 responses.
+	ErrRateLimited = errors.New("aidr: rate limited")
+	// ErrValidation matches HTTP 400 responses and responses carrying
+	// validation errors.
+	ErrValidation = errors.New("aidr: validation failed")
+	// ErrNotFound matches HTTP 404 responses.
+	ErrNotFound = errors.New("aidr: not found")
+	// ErrServer matches HTTP 5xx responses.
+	ErrServer = errors.New("aidr: server error")
+)
+
+// Is reports whether the error matches one of the sentinel errors of this
+// package.
+func (r *Error) Is(target error) bool {
+	switch target {
+	case ErrAuthentication:
+		return r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return r.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return r.StatusCode == http.StatusBadRequest || len(r.Result.Errors) > 0
+	case ErrNotFound:
+		return r.StatusCode == http.StatusNotFound
+	case ErrServer:
+		return r.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// ValidationErrors returns the per-field validation errors reported by the API,
+// if any.
+func (r *Error) ValidationErrors() []ValidationError {
+	return r.Result.Errors
+}
+
+type ErrorResult struct {
+	Errors []ValidationError `json:"errors"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Errors      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ErrorResult) RawJSON() string { return r.JSON.raw }
+
+func (r *ErrorResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ValidationError struct {
+	// Any of "FieldRequired", "InvalidString", "InvalidNumber", "InvalidInteger",
+	// "InvalidObject", "InvalidArray", "InvalidNull", "InvalidBool", "BadFormat",
+	// "BadFormatPangeaDuration", "BadFormatDateTime", "BadFormatTime",
+	// "BadFormatDate", "BadFormatEmail", "BadFormatHostname", "BadFormatIPv4",
+	// "BadFormatIPv6", "BadFormatIPAddress", "BadFormatUUID", "BadFormatURI",
+	// "BadFormatURIReference", "BadFormatIRI", "BadFormatIRIReference",
+	// "BadFormatJSONPointer", "BadFormatRelativeJSONPointer", "BadFormatRegex",
+	// "BadFormatJSONPath", "BadFormatBase64", "DoesNotMatchPattern",
+	// "DoesNotMatchPatternProperties", "NotEnumMember", "AboveMaxLength",
+	// "BelowMinLength", "AboveMaxItems", "BelowMinItems", "NotMultipleOf",
+	// "NotWithinRange", "UnexpectedProperty", "InvalidPropertyName",
+	// "AboveMaxProperties", "BelowMinProperties", "NotContains", "ContainsTooMany",
+	// "ContainsTooFew", "ItemNotUnique", "UnexpectedAdditionalItem",
+	// "InvalidConst", "IsDependentOn", "IsTooBig", "IsTooSmall",
+	// "ShouldNotBeValid", "NoUnevaluatedItems", "NoUnevaluatedProperties",
+	// "DoesNotExist", "IsReadOnly", "CannotAddToDefault", "MustProvideOne",
+	// "MutuallyExclusive", "BadState", "InaccessibleURI", "ProviderDisabled",
+	// "ConfigProjectMismatch", "ConfigServiceMismatch", "ConfigNotExist"
+	Code ValidationErrorCode `json:"code,required"`
+	// Human readable description of the error
+	Detail string `json:"detail,required"`
+	// Path to the data source of the error
+	Source string `json:"source,required" format:"json-pointer"`
+	// The Schema path where the error ocurred
+	Path string `json:"path" format:"json-pointer"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Code        respjson.Field
+		Detail      respjson.Field
+		Source      respjson.Field
+		Path        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ValidationError) RawJSON() string { return r.JSON.raw }
+
+func (r *ValidationError) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ValidationErrorCode string
+
+const (
+	ValidationErrorCodeFieldRequired                 ValidationErrorCode = "FieldRequired"
+	ValidationErrorCodeInvalidString                 ValidationErrorCode = "InvalidString"
+	ValidationErrorCodeInvalidNumber                 ValidationErrorCode = "InvalidNumber"
+	ValidationErrorCodeInvalidInteger                ValidationErrorCode = "InvalidInteger"
+	ValidationErrorCodeInvalidObject                 ValidationErrorCode = "InvalidObject"
+	ValidationErrorCodeInvalidArray                  ValidationErrorCode = "InvalidArray"
+	ValidationErrorCodeInvalidNull                   ValidationErrorCode = "InvalidNull"
+	ValidationErrorCodeInvalidBool                   ValidationErrorCode = "InvalidBool"
+	ValidationErrorCodeBadFormat                     ValidationErrorCode = "BadFormat"
+	ValidationErrorCodeBadFormatPangeaDuration       ValidationErrorCode = "BadFormatPangeaDuration"
+	ValidationErrorCodeBadFormatDateTime             ValidationErrorCode = "BadFormatDateTime"
+	ValidationErrorCodeBadFormatTime                 ValidationErrorCode = "BadFormatTime"
+	ValidationErrorCodeBadFormatDate                 ValidationErrorCode = "BadFormatDate"
+	ValidationErrorCodeBadFormatEmail                ValidationErrorCode = "BadFormatEmail"
+	ValidationErrorCodeBadFormatHostname             ValidationErrorCode = "BadFormatHostname"
+	ValidationErrorCodeBadFormatIPv4                 ValidationErrorCode = "BadFormatIPv4"
+	ValidationErrorCodeBadFormatIPv6                 ValidationErrorCode = "BadFormatIPv6"
+	ValidationErrorCodeBadFormatIPAddress            ValidationErrorCode = "BadFormatIPAddress"
+	ValidationErrorCodeBadFormatUUID                 ValidationErrorCode = "BadFormatUUID"
+	ValidationErrorCodeBadFormatURI                  ValidationErrorCode = "BadFormatURI"
+	ValidationErrorCodeBadFormatURIReference         ValidationErrorCode = "BadFormatURIReference"
+	ValidationErrorCodeBadFormatIRI                  ValidationErrorCode = "BadFormatIRI"
+	ValidationErrorCodeBadFormatIRIReference         ValidationErrorCode = "BadFormatIRIReference"
+	ValidationErrorCodeBadFormatJSONPointer          ValidationErrorCode = "BadFormatJSONPointer"
+	ValidationErrorCodeBadFormatRelativeJSONPointer  ValidationErrorCode = "BadFormatRelativeJSONPointer"
+	ValidationErrorCodeBadFormatRegex                ValidationErrorCode = "BadFormatRegex"
+	ValidationErrorCodeBadFormatJSONPath             ValidationErrorCode = "BadFormatJSONPath"
+	ValidationErrorCodeBadFormatBase64               ValidationErrorCode = "BadFormatBase64"
+	ValidationErrorCodeDoesNotMatchPattern           ValidationErrorCode = "DoesNotMatchPattern"
+	ValidationErrorCodeDoesNotMatchPatternProperties ValidationErrorCode = "DoesNotMatchPatternProperties"
+	ValidationErrorCodeNotEnumMember                 ValidationErrorCode = "NotEnumMember"
+	ValidationErrorCodeAboveMaxLength                ValidationErrorCode = "AboveMaxLength"
+	ValidationErrorCodeBelowMinLength                ValidationErrorCode = "BelowMinLength"
+	ValidationErrorCodeAboveMaxItems                 ValidationErrorCode = "AboveMaxItems"
+	ValidationErrorCodeBelowMinItems                 ValidationErrorCode = "BelowMinItems"
+	ValidationErrorCodeNotMultipleOf                 ValidationErrorCode = "NotMultipleOf"
+	ValidationErrorCodeNotWithinRange                ValidationErrorCode = "NotWithinRange"
+	ValidationErrorCodeUnexpectedProperty            ValidationErrorCode = "UnexpectedProperty"
+	ValidationErrorCodeInvalidPropertyName           ValidationErrorCode = "InvalidPropertyName"
+	ValidationErrorCodeAboveMaxProperties            ValidationErrorCode = "AboveMaxProperties"
+	ValidationErrorCodeBelowMinProperties            ValidationErrorCode = "BelowMinProperties"
+	ValidationErrorCodeNotContains                   ValidationErrorCode = "NotContains"
+	ValidationErrorCodeContainsTooMany               ValidationErrorCode = "ContainsTooMany"
+	ValidationErrorCodeContainsTooFew                ValidationErrorCode = "ContainsTooFew"
+	ValidationErrorCodeItemNotUnique                 ValidationErrorCode = "ItemNotUnique"
+	ValidationErrorCodeUnexpectedAdditionalItem      ValidationErrorCode = "UnexpectedAdditionalItem"
+	ValidationErrorCodeInvalidConst                  ValidationErrorCode = "InvalidConst"
+	ValidationErrorCodeIsDependentOn                 ValidationErrorCode = "IsDependentOn"
+	ValidationErrorCodeIsTooBig                      ValidationErrorCode = "IsTooBig"
+	ValidationErrorCodeIsTooSmall                    ValidationErrorCode = "IsTooSmall"
+	ValidationErrorCodeShouldNotBeValid              ValidationErrorCode = "ShouldNotBeValid"
+	ValidationErrorCodeNoUnevaluatedItems            ValidationErrorCode = "NoUnevaluatedItems"
+	ValidationErrorCodeNoUnevaluatedProperties       ValidationErrorCode = "NoUnevaluatedProperties"
+	ValidationErrorCodeDoesNotExist                  ValidationErrorCode = "DoesNotExist"
+	ValidationErrorCodeIsReadOnly                    ValidationErrorCode = "IsReadOnly"
+	ValidationErrorCodeCannotAddToDefault            ValidationErrorCode = "CannotAddToDefault"
+	ValidationErrorCodeMustProvideOne                ValidationErrorCode = "MustProvideOne"
+	ValidationErrorCodeMutuallyExclusive             ValidationErrorCode = "MutuallyExclusive"
+	ValidationErrorCodeBadState                      ValidationErrorCode = "BadState"
+	ValidationErrorCodeInaccessibleURI               ValidationErrorCode = "InaccessibleURI"
+	ValidationErrorCodeProviderDisabled              ValidationErrorCode = "ProviderDisabled"
+	ValidationErrorCodeConfigProjectMismatch         ValidationErrorCode = "ConfigProjectMismatch"
+	ValidationErrorCodeConfigServiceMismatch         ValidationErrorCode = "ConfigServiceMismatch"
+	ValidationErrorCodeConfigNotExist                ValidationErrorCode = "ConfigNotExist"
+)
@@ -0,0 +1,141 @@
+package requestconfig
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Failover spreads requests over an ordered list of base URL templates, such as
+// several regions of the API. Requests go to the first healthy endpoint. An
+// endpoint that fails with a connection error or an HTTP/5xx response is marked
+// unhealthy and skipped until its cooldown elapses. Create one with
+// [NewFailover].
+//
+// A Failover is safe for concurrent use, and its health tracking is shared by
+// all the requests using it.
+type Failover struct {
+	templates []string
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu             sync.Mutex
+	unhealthyUntil []time.Time
+}
+
+// FailoverEndpoint describes the health of one endpoint of a [Failover].
+type FailoverEndpoint struct {
+	BaseURLTemplate string
+	Healthy         bool
+	// UnhealthyUntil is when the endpoint will be tried again, zero if it is
+	// healthy.
+	UnhealthyUntil time.Time
+}
+
+// NewFailover returns a [Failover] over baseURLTemplates, in order of
+// preference. Each template may contain {SERVICE_NAME} placeholders. An
+// endpoint that fails is skipped for cooldown.
+func NewFailover(cooldown time.Duration, baseURLTemplates ...string) *Failover {
+	return &Failover{
+		templates:      baseURLTemplates,
+		cooldown:       cooldown,
+		now:            time.Now,
+		unhealthyUntil: make([]time.Time, len(baseURLTemplates)),
+	}
+}
+
+// Endpoints reports the health of every endpoint, in order of preference.
+func (f *Failover) Endpoints() []FailoverEndpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	endpoints := make([]FailoverEndpoint, len(f.templates))
+	for i, template := range f.templates {
+		endpoints[i] = FailoverEndpoint{BaseURLTemplate: template, Healthy: true}
+		if now.Before(f.unhealthyUntil[i]) {
+			endpoints[i].Healthy = false
+			endpoints[i].UnhealthyUntil = f.unhealthyUntil[i]
+		}
+	}
+	return endpoints
+}
+
+// pick returns the endpoint for the next attempt: the first healthy one not in
+// tried, or the first healthy one if all have been tried. When every endpoint is
+// cooling down, the one that recovers first is used.
+func (f *Failover) pick(tried map[int]bool) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	healthy := -1
+	for i := range f.templates {
+		if now.Before(f.unhealthyUntil[i]) {
+			continue
+		}
+		if !tried[i] {
+			return i
+		}
+		if healthy < 0 {
+			healthy = i
+		}
+	}
+	if healthy >= 0 {
+		return healthy
+	}
+
+	soonest := 0
+	for i := range f.templates {
+		if f.unhealthyUntil[i].Before(f.unhealthyUntil[soonest]) {
+			soonest = i
+		}
+	}
+	return soonest
+}
+
+// hasUntried reports whether a healthy endpoint is left outside of tried.
+func (f *Failover) hasUntried(tried map[int]bool) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	for i := range f.templates {
+		if !tried[i] && !now.Before(f.unhealthyUntil[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Failover) report(i int, healthy bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if healthy {
+		f.unhealthyUntil[i] = time.Time{}
+	} else {
+		f.unhealthyUntil[i] = f.now().Add(f.cooldown)
+	}
+}
+
+// resolveBaseURL replaces the {SERVICE_NAME} placeholders of template and parses
+// the result as a base URL.
+func resolveBaseURL(template, serviceName string) (*url.URL, error) {
+	if strings.Contains(template, "{SERVICE_NAME}") {
+		if serviceName == "" {
+			return nil, fmt.Errorf("requestconfig: {SERVICE_NAME} placeholder found in base URL template but no service name provided")
+		}
+		template = strings.ReplaceAll(template, "{SERVICE_NAME}", serviceName)
+	}
+	u, err := url.Parse(template)
+	if err != nil {
+		return nil, fmt.Errorf("requestconfig: failed to parse base URL template: %w", err)
+	}
+	if u.Path != "" && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
@@ -0,0 +1,57 @@
+package requestconfig
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFailoverPick(t *testing.T) {
+	now := time.Unix(0, 0)
+	f := NewFailover(time.Minute, "https://a/{SERVICE_NAME}", "https://b/{SERVICE_NAME}", "https://c/{SERVICE_NAME}")
+	f.now = func() time.Time { return now }
+
+	if i := f.pick(nil); i != 0 {
+		t.Fatalf("expected the first endpoint, received %d", i)
+	}
+	if i := f.pick(map[int]bool{0: true}); i != 1 {
+		t.Fatalf("expected the next untried endpoint, received %d", i)
+	}
+
+	f.report(0, false)
+	if i := f.pick(nil); i != 1 {
+		t.Fatalf("expected an unhealthy endpoint to be skipped, received %d", i)
+	}
+	if f.hasUntried(map[int]bool{1: true, 2: true}) {
+		t.Fatal("an unhealthy endpoint should not count as untried")
+	}
+	if endpoints := f.Endpoints(); endpoints[0].Healthy || !endpoints[0].UnhealthyUntil.Equal(now.Add(time.Minute)) || !endpoints[1].Healthy {
+		t.Fatalf("unexpected endpoints %+v", endpoints)
+	}
+
+	now = now.Add(30 * time.Second)
+	f.report(1, false)
+	f.report(2, false)
+	if i := f.pick(nil); i != 0 {
+		t.Fatalf("expected the endpoint recovering first, received %d", i)
+	}
+
+	now = now.Add(30 * time.Second)
+	if i := f.pick(nil); i != 0 || !f.Endpoints()[0].Healthy {
+		t.Fatalf("expected the endpoint to recover after its cooldown, received %d", i)
+	}
+
+	f.report(1, true)
+	if !f.Endpoints()[1].Healthy {
+		t.Fatal("expected a success to mark the endpoint healthy")
+	}
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	u, err := resolveBaseURL("https://api.eu-1.crowdstrike.com/aidr/{SERVICE_NAME}", "aiguard")
+	if err != nil || u.String() != "https://api.eu-1.crowdstrike.com/aidr/aiguard/" {
+		t.Fatalf("unexpected base URL %v (%v)", u, err)
+	}
+	if _, err := resolveBaseURL("https://a/{SERVICE_NAME}", ""); err == nil {
+		t.Fatal("expected an error without a service name")
+	}
+}
@@ -6,14 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"math"
-	"math/rand"
 	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/crowdstrike/aidr-go/internal"
@@ -155,10 +154,19 @@ type RequestConfig struct {
 	CustomHTTPDoer HTTPDoer
 	HTTPClient     *http.Client
 	Middlewares    []middleware
-	Token          string
-	// ServiceTokens stores service-specific tokens keyed by service name.
-	// Service-specific tokens override the client-level Token when present.
-	ServiceTokens sync.Map // map[string]string
+	// Failover, if set, replaces BaseURLTemplate with an ordered list of base
+	// URL templates, moving to the next one when an endpoint fails.
+	Failover *Failover
+	// RetryPolicy customizes which failures are retried and how long to wait
+	// between attempts. The default policy is used when it is nil.
+	RetryPolicy *RetryPolicy
+	Token       string
+	// TokenProvider supplies the client-level token on every attempt. It takes
+	// precedence over Token when set.
+	TokenProvider TokenProvider
+	// ServiceTokens stores service-specific token providers keyed by service
+	// name. Service-specific tokens override the client-level token when present.
+	ServiceTokens map[string]TokenProvider
 	// If ResponseBodyInto not nil, then we will attempt to deserialize into
 	// ResponseBodyInto. If Destination is a []byte, then it will return the body as
 	// is.
@@ -167,6 +175,10 @@ type RequestConfig struct {
 	// given address
 	ResponseInto **http.Response
 	Body         io.Reader
+	// AcceptedAsError reports a 202 Accepted response as an
+	// [apierror.AcceptedError] instead of decoding it into ResponseBodyInto.
+	// Only endpoints that may defer processing should set it.
+	AcceptedAsError bool
 }
 
 // middleware is exactly the same type as the Middleware type found in the [option] package,
@@ -183,7 +195,17 @@ func applyMiddleware(middleware middleware, next middlewareNext) middlewareNext
 	}
 }
 
-func shouldRetry(req *http.Request, res *http.Response) bool {
+type attemptKey struct{}
+
+// Attempt returns the 1-based attempt number of the request being executed with
+// ctx, or 0 when ctx does not belong to a request made by [RequestConfig.Execute].
+// It is useful for middlewares, which are called once per attempt.
+func Attempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
+
+func shouldRetry(policy *RetryPolicy, req *http.Request, res *http.Response, err error) bool {
 	// If there is no way to recover the Body, then we shouldn't retry.
 	if req.Body != nil && req.GetBody == nil {
 		return false
@@ -192,7 +214,7 @@ func shouldRetry(req *http.Request, res *http.Response) bool {
 	// If there is no response, that indicates that there is a connection error
 	// so we retry the request.
 	if res == nil {
-		return true
+		return policy.retryableError(err)
 	}
 
 	// If the header explicitly wants a retry behavior, respect that over the
@@ -204,10 +226,7 @@ func shouldRetry(req *http.Request, res *http.Response) bool {
 		return false
 	}
 
-	return res.StatusCode == http.StatusRequestTimeout ||
-		res.StatusCode == http.StatusConflict ||
-		res.StatusCode == http.StatusTooManyRequests ||
-		res.StatusCode >= http.StatusInternalServerError
+	return policy.retryableStatus(res.StatusCode)
 }
 
 func parseRetryAfterHeader(resp *http.Response) (time.Duration, bool) {
@@ -300,7 +319,15 @@ func (b *bodyWithTimeout) Close() error {
 	return err
 }
 
-func retryDelay(res *http.Response, retryCount int) time.Duration {
+// BackoffDelay returns how long to wait before the attempt following retryCount.
+// A reasonable Retry-After-Ms or Retry-After header on res takes precedence,
+// otherwise the delay grows exponentially from baseDelay up to maxDelay, minus
+// up to 25% of jitter.
+func BackoffDelay(res *http.Response, retryCount int, baseDelay, maxDelay time.Duration) time.Duration {
+	return backoffDelay(res, retryCount, baseDelay, maxDelay, JitterProportional)
+}
+
+func backoffDelay(res *http.Response, retryCount int, baseDelay, maxDelay time.Duration, jitter Jitter) time.Duration {
 	// If the API asks us to wait a certain amount of time (and it's a reasonable amount),
 	// just do what it says.
 
@@ -308,35 +335,37 @@ func retryDelay(res *http.Response, retryCount int) time.Duration {
 		return retryAfterDelay
 	}
 
-	maxDelay := 8 * time.Second
-	delay := time.Duration(0.5 * float64(time.Second) * math.Pow(2, float64(retryCount)))
+	delay := time.Duration(float64(baseDelay) * math.Pow(2, float64(retryCount)))
 	if delay > maxDelay {
 		delay = maxDelay
 	}
 
-	jitter := rand.Int63n(int64(delay / 4))
-	delay -= time.Duration(jitter)
-	return delay
+	return jitter.apply(delay)
 }
 
 func (cfg *RequestConfig) Execute() (err error) {
 	// Handle BaseURLTemplate: replace {SERVICE_NAME} placeholders and parse
-	if cfg.BaseURLTemplate != "" {
-		baseURLStr := cfg.BaseURLTemplate
-		if strings.Contains(baseURLStr, "{SERVICE_NAME}") {
-			if cfg.ServiceName == "" {
-				return fmt.Errorf("requestconfig: {SERVICE_NAME} placeholder found in base URL template but no service name provided")
-			}
-			baseURLStr = strings.ReplaceAll(baseURLStr, "{SERVICE_NAME}", cfg.ServiceName)
-		}
-		u, err := url.Parse(baseURLStr)
+	if cfg.BaseURLTemplate != "" && cfg.Failover == nil {
+		cfg.BaseURL, err = resolveBaseURL(cfg.BaseURLTemplate, cfg.ServiceName)
 		if err != nil {
-			return fmt.Errorf("requestconfig: failed to parse base URL template: %w", err)
+			return err
 		}
-		if u.Path != "" && !strings.HasSuffix(u.Path, "/") {
-			u.Path += "/"
+	}
+
+	// With a failover, the base URL is picked again before every attempt.
+	var endpoints []*url.URL
+	if cfg.Failover != nil {
+		if len(cfg.Failover.templates) == 0 {
+			return fmt.Errorf("requestconfig: failover has no base URL templates")
+		}
+		endpoints = make([]*url.URL, len(cfg.Failover.templates))
+		for i, template := range cfg.Failover.templates {
+			endpoints[i], err = resolveBaseURL(template, cfg.ServiceName)
+			if err != nil {
+				return err
+			}
 		}
-		cfg.BaseURL = u
+		cfg.BaseURL = endpoints[0]
 	}
 
 	if cfg.BaseURL == nil {
@@ -347,25 +376,12 @@ func (cfg *RequestConfig) Execute() (err error) {
 		}
 	}
 
-	cfg.Request.URL, err = cfg.BaseURL.Parse(strings.TrimLeft(cfg.Request.URL.String(), "/"))
+	path := strings.TrimLeft(cfg.Request.URL.String(), "/")
+	cfg.Request.URL, err = cfg.BaseURL.Parse(path)
 	if err != nil {
 		return err
 	}
 
-	// Resolve token: service-specific token takes precedence over client-level token
-	resolvedToken := cfg.Token
-	if cfg.ServiceName != "" {
-		if serviceToken, ok := cfg.ServiceTokens.Load(cfg.ServiceName); ok {
-			if tokenStr, ok := serviceToken.(string); ok {
-				resolvedToken = tokenStr
-			}
-		}
-	}
-	// Set Authorization header if a token is available
-	if resolvedToken != "" {
-		cfg.Request.Header.Set("authorization", fmt.Sprintf("Bearer %s", resolvedToken))
-	}
-
 	if cfg.Body != nil && cfg.Request.Body == nil {
 		switch body := cfg.Body.(type) {
 		case *bytes.Buffer:
@@ -397,8 +413,16 @@ func (cfg *RequestConfig) Execute() (err error) {
 		handler = applyMiddleware(cfg.Middlewares[i], handler)
 	}
 
+	// Resolve token: service-specific token takes precedence over client-level token
+	provider := cfg.tokenProvider()
+	reauthenticated := false
+
 	var res *http.Response
 	var cancel context.CancelFunc
+	clock := cfg.RetryPolicy.clock()
+	start := clock.Now()
+	attempt := 0
+	tried := map[int]bool{}
 	for retryCount := 0; retryCount <= cfg.MaxRetries; retryCount += 1 {
 		ctx := cfg.Request.Context()
 		if cfg.RequestTimeout != time.Duration(0) && isBeforeContextDeadline(time.Now().Add(cfg.RequestTimeout), ctx) {
@@ -411,16 +435,88 @@ func (cfg *RequestConfig) Execute() (err error) {
 			}()
 		}
 
-		req := cfg.Request.Clone(ctx)
+		endpoint := -1
+		if cfg.Failover != nil {
+			endpoint = cfg.Failover.pick(tried)
+			tried[endpoint] = true
+			cfg.BaseURL = endpoints[endpoint]
+			cfg.Request.URL, err = cfg.BaseURL.Parse(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		attempt += 1
+		req := cfg.Request.Clone(context.WithValue(ctx, attemptKey{}, attempt))
+
+		// Set Authorization header if a token is available
+		var token string
+		if provider != nil {
+			token, err = provider.Token(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		if token != "" {
+			req.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
+		}
 
 		res, err = handler(req)
 		if ctx != nil && ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if !shouldRetry(cfg.Request, res) || retryCount >= cfg.MaxRetries {
+
+		// The token may have been revoked or rotated. Retry once, without using
+		// up a retry, if the provider has a different token to offer.
+		if err == nil && res.StatusCode == http.StatusUnauthorized && token != "" && !reauthenticated {
+			reauthenticated = true
+			provider.Invalidate(token)
+			if fresh, ferr := provider.Token(ctx); ferr == nil && fresh != token {
+				if ok, err := cfg.rewindBody(); err != nil {
+					return err
+				} else if ok {
+					res.Body.Close()
+					retryCount -= 1
+					continue
+				}
+			}
+		}
+
+		// Move on to the next healthy endpoint right away, without using up a
+		// retry, until every endpoint has been tried.
+		if endpoint >= 0 {
+			failed := err != nil || res.StatusCode >= http.StatusInternalServerError
+			cfg.Failover.report(endpoint, !failed)
+			if failed && cfg.Failover.hasUntried(tried) {
+				if ok, err := cfg.rewindBody(); err != nil {
+					return err
+				} else if ok {
+					if res != nil && res.Body != nil {
+						res.Body.Close()
+					}
+					retryCount -= 1
+					continue
+				}
+			}
+		}
+
+		if !shouldRetry(cfg.RetryPolicy, cfg.Request, res, err) || retryCount >= cfg.MaxRetries {
 			break
 		}
 
+		delay := cfg.RetryPolicy.delay(res, retryCount)
+		if policy := cfg.RetryPolicy; policy != nil {
+			if policy.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+				break
+			}
+			if policy.Budget != nil && !policy.Budget.withdraw(clock.Now()) {
+				break
+			}
+			if policy.OnRetry != nil {
+				policy.OnRetry(RetryEvent{Attempt: retryCount + 1, Delay: delay, Response: res, Err: err})
+			}
+		}
+
 		// Prepare next request and wait for the retry delay
 		if cfg.Request.GetBody != nil {
 			cfg.Request.Body, err = cfg.Request.GetBody()
@@ -439,7 +535,9 @@ func (cfg *RequestConfig) Execute() (err error) {
 			res.Body.Close()
 		}
 
-		time.Sleep(retryDelay(res, retryCount))
+		if err := clock.Sleep(cfg.Request.Context(), delay); err != nil {
+			return err
+		}
 	}
 
 	// Save *http.Response if it is requested to, even if there was an error making the request. This is
@@ -478,6 +576,27 @@ func (cfg *RequestConfig) Execute() (err error) {
 		return &aerr
 	}
 
+	// A 202 means the request was queued and has no result yet. Surface it as an
+	// error so a pending request can never be mistaken for a final response.
+	if cfg.AcceptedAsError && res.StatusCode == http.StatusAccepted {
+		contents, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		res.Body = io.NopCloser(bytes.NewBuffer(contents))
+
+		aerr := apierror.AcceptedError{Request: cfg.Request, Response: res, StatusCode: res.StatusCode}
+		if len(bytes.TrimSpace(contents)) > 0 {
+			err = aerr.UnmarshalJSON(contents)
+			if err != nil {
+				return err
+			}
+		}
+		return &aerr
+	}
+
 	_, intoCustomResponseBody := cfg.ResponseBodyInto.(**http.Response)
 	if cfg.ResponseBodyInto == nil || intoCustomResponseBody {
 		// We aren't reading the response body in this scope, but whoever is will need the
@@ -529,6 +648,20 @@ func (cfg *RequestConfig) Execute() (err error) {
 	return nil
 }
 
+// rewindBody prepares the request body to be sent again, reporting false if it
+// cannot be.
+func (cfg *RequestConfig) rewindBody() (bool, error) {
+	if cfg.Request.GetBody == nil {
+		return cfg.Request.Body == nil, nil
+	}
+	body, err := cfg.Request.GetBody()
+	if err != nil {
+		return false, err
+	}
+	cfg.Request.Body = body
+	return true, nil
+}
+
 func ExecuteNewRequest(ctx context.Context, method, u string, body, dst any, opts ...RequestOption) error {
 	cfg, err := NewRequestConfig(ctx, method, u, body, dst, opts...)
 	if err != nil {
@@ -559,7 +692,12 @@ func (cfg *RequestConfig) Clone(ctx context.Context) *RequestConfig {
 		ServiceName:     cfg.ServiceName,
 		HTTPClient:      cfg.HTTPClient,
 		Middlewares:     cfg.Middlewares,
+		Failover:        cfg.Failover,
+		RetryPolicy:     cfg.RetryPolicy,
 		Token:           cfg.Token,
+		TokenProvider:   cfg.TokenProvider,
+		ServiceTokens:   maps.Clone(cfg.ServiceTokens),
+		AcceptedAsError: cfg.AcceptedAsError,
 	}
 
 	return new
@@ -607,3 +745,13 @@ func WithDefaultBaseURL(baseURL string) RequestOption {
 		return nil
 	})
 }
+
+// WithAcceptedError returns a RequestOption that reports a 202 Accepted response
+// as an [apierror.AcceptedError]. It is internal so that only endpoints which can
+// defer processing opt in.
+func WithAcceptedError() RequestOption {
+	return RequestOptionFunc(func(r *RequestConfig) error {
+		r.AcceptedAsError = true
+		return nil
+	})
+}
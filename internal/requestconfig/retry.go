@@ -0,0 +1,203 @@
+package requestconfig
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RetryPolicy customizes when and how failed requests are retried. The maximum
+// number of retries is still controlled by [RequestConfig.MaxRetries].
+//
+// The zero value retries like the default policy: connection errors, HTTP/408,
+// HTTP/409, HTTP/429 and HTTP/5xx responses, with an exponential backoff from
+// 0.5 to 8 seconds and proportional jitter.
+type RetryPolicy struct {
+	// RetryableStatusCodes replaces the default list of retryable status codes
+	// when it is not nil.
+	RetryableStatusCodes []int
+	// RetryableError reports whether a request that failed without a response,
+	// such as on a connection error, should be retried. All such errors are
+	// retried when it is nil.
+	RetryableError func(err error) bool
+	// BaseDelay is the delay before the first retry. Defaults to 0.5 seconds.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts. Defaults to 8 seconds.
+	MaxDelay time.Duration
+	// Jitter randomizes the delays between attempts. Defaults to
+	// [JitterProportional].
+	Jitter Jitter
+	// MaxElapsed bounds the total time spent on a request, retries included.
+	// No retry is attempted if it would start after MaxElapsed. Zero means no
+	// limit.
+	MaxElapsed time.Duration
+	// Budget, if set, is shared across requests and limits how many retries
+	// they can make altogether.
+	Budget *RetryBudget
+	// OnRetry, if set, is called before waiting for each retry.
+	OnRetry func(RetryEvent)
+	// Clock is used to measure elapsed time and wait between attempts. Defaults
+	// to the system clock.
+	Clock Clock
+}
+
+// RetryEvent describes a retry that is about to happen.
+type RetryEvent struct {
+	// The 1-based number of the attempt that failed.
+	Attempt int
+	// The delay before the next attempt.
+	Delay time.Duration
+	// The response of the failed attempt, nil on connection errors.
+	Response *http.Response
+	// The error of the failed attempt, nil if a response was received.
+	Err error
+}
+
+// Jitter is a strategy to randomize the delay between two attempts, which
+// avoids retries from many clients happening at the same time.
+type Jitter int8
+
+const (
+	// JitterProportional subtracts up to 25% of the delay.
+	JitterProportional Jitter = iota
+	// JitterNone uses the delay unchanged.
+	JitterNone
+	// JitterFull picks a delay between zero and the delay.
+	JitterFull
+	// JitterEqual picks a delay between half the delay and the delay.
+	JitterEqual
+)
+
+func (j Jitter) apply(delay time.Duration) time.Duration {
+	switch j {
+	case JitterNone:
+		return delay
+	case JitterFull:
+		if delay <= 0 {
+			return delay
+		}
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	case JitterEqual:
+		if delay/2 <= 0 {
+			return delay
+		}
+		return delay - delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	default:
+		if delay/4 <= 0 {
+			return delay
+		}
+		return delay - time.Duration(rand.Int63n(int64(delay/4)))
+	}
+}
+
+// Clock abstracts time so that retries can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for the duration to elapse, or returns the error of ctx if it
+	// is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (p *RetryPolicy) clock() Clock {
+	if p == nil || p.Clock == nil {
+		return systemClock{}
+	}
+	return p.Clock
+}
+
+func (p *RetryPolicy) retryableStatus(statusCode int) bool {
+	if p != nil && p.RetryableStatusCodes != nil {
+		return slices.Contains(p.RetryableStatusCodes, statusCode)
+	}
+	return statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusConflict ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
+
+func (p *RetryPolicy) retryableError(err error) bool {
+	if p != nil && p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return true
+}
+
+func (p *RetryPolicy) delay(res *http.Response, retryCount int) time.Duration {
+	if p == nil {
+		return BackoffDelay(res, retryCount, 500*time.Millisecond, 8*time.Second)
+	}
+	baseDelay, maxDelay := p.BaseDelay, p.MaxDelay
+	if baseDelay <= 0 {
+		baseDelay = 500 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 8 * time.Second
+	}
+	return backoffDelay(res, retryCount, baseDelay, maxDelay, p.Jitter)
+}
+
+// RetryBudget limits the number of retries made by all the requests sharing
+// it, so that a failing API is not flooded with retries. Create one with
+// [NewRetryBudget].
+type RetryBudget struct {
+	mu       sync.Mutex
+	max      float64
+	tokens   float64
+	per      time.Duration
+	lastFill time.Time
+}
+
+// NewRetryBudget returns a budget allowing up to retries retries per period,
+// refilled continuously.
+func NewRetryBudget(retries int, per time.Duration) *RetryBudget {
+	return &RetryBudget{
+		max:    float64(retries),
+		tokens: float64(retries),
+		per:    per,
+	}
+}
+
+// Withdraw consumes one retry from the budget, reporting false if none is
+// left.
+func (b *RetryBudget) Withdraw() bool {
+	return b.withdraw(time.Now())
+}
+
+// withdraw is like Withdraw, refilling the budget up to now. Requests pass the
+// time of their [RetryPolicy.Clock].
+func (b *RetryBudget) withdraw(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.lastFill.IsZero() && b.per > 0 {
+		b.tokens += b.max * float64(now.Sub(b.lastFill)) / float64(b.per)
+		if b.tokens > b.max {
+			b.tokens = b.max
+		}
+	}
+	b.lastFill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
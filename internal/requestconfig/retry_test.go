@@ -0,0 +1,67 @@
+package requestconfig
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Unix(0, 0)
+	budget := NewRetryBudget(2, time.Minute)
+
+	if !budget.withdraw(now) || !budget.withdraw(now) {
+		t.Fatalf("expected the first two retries to be allowed")
+	}
+	if budget.withdraw(now) {
+		t.Fatalf("expected the budget to be exhausted")
+	}
+
+	now = now.Add(30 * time.Second)
+	if !budget.withdraw(now) {
+		t.Fatalf("expected the budget to be refilled")
+	}
+	if budget.withdraw(now) {
+		t.Fatalf("expected the budget to be exhausted again")
+	}
+}
+
+func TestJitter(t *testing.T) {
+	delay := time.Second
+	for range 100 {
+		if d := JitterNone.apply(delay); d != delay {
+			t.Fatalf("expected no jitter, received %s", d)
+		}
+		if d := JitterProportional.apply(delay); d <= 3*delay/4 || d > delay {
+			t.Fatalf("proportional jitter out of range: %s", d)
+		}
+		if d := JitterFull.apply(delay); d < 0 || d > delay {
+			t.Fatalf("full jitter out of range: %s", d)
+		}
+		if d := JitterEqual.apply(delay); d < delay/2 || d > delay {
+			t.Fatalf("equal jitter out of range: %s", d)
+		}
+	}
+}
+
+func TestRetryPolicyStatusCodes(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	res := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+
+	if !shouldRetry(nil, req, res, nil) {
+		t.Errorf("expected 503 to be retried by default")
+	}
+	policy := &RetryPolicy{RetryableStatusCodes: []int{http.StatusTooManyRequests}}
+	if shouldRetry(policy, req, res, nil) {
+		t.Errorf("expected 503 not to be retried")
+	}
+	res.StatusCode = http.StatusTooManyRequests
+	if !shouldRetry(policy, req, res, nil) {
+		t.Errorf("expected 429 to be retried")
+	}
+
+	policy.RetryableError = func(error) bool { return false }
+	if shouldRetry(policy, req, nil, http.ErrHandlerTimeout) {
+		t.Errorf("expected connection error not to be retried")
+	}
+}
@@ -0,0 +1,166 @@
+package requestconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// TokenProvider supplies the API token for a request. Token is called before
+// every attempt, so a provider can rotate credentials without rebuilding the
+// client. Implementations must be safe for concurrent use.
+type TokenProvider interface {
+	// Token returns the token to send with the next attempt.
+	Token(ctx context.Context) (string, error)
+	// Invalidate reports that the API rejected token. Providers that cache
+	// tokens should discard it so that the next call to Token fetches a fresh
+	// one.
+	Invalidate(token string)
+}
+
+// StaticToken is a [TokenProvider] that always returns the same token.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) { return string(t), nil }
+
+func (StaticToken) Invalidate(string) {}
+
+// RefreshingTokenProvider is a [TokenProvider] that caches the token returned by
+// a callback until it expires. Create one with [NewRefreshingTokenProvider].
+type RefreshingTokenProvider struct {
+	fetch  func(ctx context.Context) (string, time.Time, error)
+	leeway time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewRefreshingTokenProvider returns a provider that calls fetch for a token and
+// its expiry time, and calls it again leeway before the token expires or when
+// the API rejects it. A zero expiry time means the token never expires.
+func NewRefreshingTokenProvider(fetch func(ctx context.Context) (token string, expiresAt time.Time, err error), leeway time.Duration) *RefreshingTokenProvider {
+	return &RefreshingTokenProvider{fetch: fetch, leeway: leeway, now: time.Now}
+}
+
+func (p *RefreshingTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && (p.expiresAt.IsZero() || p.now().Add(p.leeway).Before(p.expiresAt)) {
+		return p.token, nil
+	}
+	token, expiresAt, err := p.fetch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("requestconfig: failed to refresh token: %w", err)
+	}
+	p.token, p.expiresAt = token, expiresAt
+	return token, nil
+}
+
+func (p *RefreshingTokenProvider) Invalidate(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == token {
+		p.token, p.expiresAt = "", time.Time{}
+	}
+}
+
+// FileTokenProvider is a [TokenProvider] that reads the token from a file, such
+// as a mounted secret. The file is not watched: it is checked for changes with
+// a stat at most once per CheckInterval, and read again when its modification
+// time or size changed. Create one with [NewFileTokenProvider].
+type FileTokenProvider struct {
+	// CheckInterval is the minimum delay between two checks of the file.
+	// Defaults to one second. A token rejected by the API is always checked
+	// again.
+	CheckInterval time.Duration
+
+	path string
+	now  func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+// NewFileTokenProvider returns a provider reading the token from the file at
+// path. Leading and trailing whitespace is ignored.
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return &FileTokenProvider{CheckInterval: time.Second, path: path, now: time.Now}
+}
+
+func (p *FileTokenProvider) Token(context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.token != "" && now.Sub(p.checkedAt) < p.CheckInterval {
+		return p.token, nil
+	}
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", fmt.Errorf("requestconfig: failed to read token file: %w", err)
+	}
+	if p.token != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		p.checkedAt = now
+		return p.token, nil
+	}
+	contents, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("requestconfig: failed to read token file: %w", err)
+	}
+	token := string(bytes.TrimSpace(contents))
+	if token == "" {
+		return "", fmt.Errorf("requestconfig: token file %s is empty", p.path)
+	}
+	p.token, p.modTime, p.size, p.checkedAt = token, info.ModTime(), info.Size(), now
+	return token, nil
+}
+
+func (p *FileTokenProvider) Invalidate(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == token {
+		p.token = ""
+	}
+}
+
+// EnvTokenProvider is a [TokenProvider] that reads the token from the named
+// environment variable on every attempt.
+type EnvTokenProvider string
+
+func (name EnvTokenProvider) Token(context.Context) (string, error) {
+	token, ok := os.LookupEnv(string(name))
+	if !ok || token == "" {
+		return "", fmt.Errorf("requestconfig: environment variable %s is not set", string(name))
+	}
+	return token, nil
+}
+
+func (EnvTokenProvider) Invalidate(string) {}
+
+// tokenProvider resolves the provider for the request: a service-specific
+// provider takes precedence over the client-level one.
+func (cfg *RequestConfig) tokenProvider() TokenProvider {
+	if cfg.ServiceName != "" {
+		if p, ok := cfg.ServiceTokens[cfg.ServiceName]; ok && p != nil {
+			return p
+		}
+	}
+	if cfg.TokenProvider != nil {
+		return cfg.TokenProvider
+	}
+	if cfg.Token != "" {
+		return StaticToken(cfg.Token)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package requestconfig
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRefreshingTokenProvider(t *testing.T) {
+	now := time.Unix(1000, 0)
+	fetches := 0
+	p := NewRefreshingTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		return "token-" + string(rune('0'+fetches)), now.Add(time.Minute), nil
+	}, 10*time.Second)
+	p.now = func() time.Time { return now }
+
+	for _, step := range []struct {
+		advance time.Duration
+		want    string
+	}{
+		{0, "token-1"},
+		{49 * time.Second, "token-1"},
+		// Within the leeway of the expiry.
+		{time.Second, "token-2"},
+	} {
+		now = now.Add(step.advance)
+		token, err := p.Token(context.Background())
+		if err != nil || token != step.want {
+			t.Fatalf("expected %q, received %q (%v)", step.want, token, err)
+		}
+	}
+
+	p.Invalidate("token-1")
+	if token, _ := p.Token(context.Background()); token != "token-2" {
+		t.Fatalf("invalidating a stale token should keep the current one, received %q", token)
+	}
+	p.Invalidate("token-2")
+	if token, _ := p.Token(context.Background()); token != "token-3" {
+		t.Fatalf("expected a fresh token after invalidation, received %q", token)
+	}
+
+	failing := NewRefreshingTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+		return "", time.Time{}, errors.New("unavailable")
+	}, 0)
+	if _, err := failing.Token(context.Background()); err == nil {
+		t.Fatal("expected an error from a failing callback")
+	}
+}
+
+func TestFileTokenProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	p := NewFileTokenProvider(path)
+	p.now = func() time.Time { return now }
+	if token, err := p.Token(context.Background()); err != nil || token != "first" {
+		t.Fatalf("expected %q, received %q (%v)", "first", token, err)
+	}
+
+	if err := os.WriteFile(path, []byte("rotated\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// The file is not checked again within CheckInterval.
+	if token, err := p.Token(context.Background()); err != nil || token != "first" {
+		t.Fatalf("expected %q, received %q (%v)", "first", token, err)
+	}
+	now = now.Add(p.CheckInterval)
+	if token, err := p.Token(context.Background()); err != nil || token != "rotated" {
+		t.Fatalf("expected %q, received %q (%v)", "rotated", token, err)
+	}
+
+	if err := os.WriteFile(path, []byte("again\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A rejected token is checked again right away.
+	p.Invalidate("rotated")
+	if token, err := p.Token(context.Background()); err != nil || token != "again" {
+		t.Fatalf("expected %q, received %q (%v)", "again", token, err)
+	}
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(p.CheckInterval)
+	if _, err := p.Token(context.Background()); err == nil {
+		t.Fatal("expected an error for an empty token file")
+	}
+}
+
+func TestEnvTokenProvider(t *testing.T) {
+	t.Setenv("AIDR_TEST_TOKEN", "from-env")
+	p := EnvTokenProvider("AIDR_TEST_TOKEN")
+	if token, err := p.Token(context.Background()); err != nil || token != "from-env" {
+		t.Fatalf("expected %q, received %q (%v)", "from-env", token, err)
+	}
+	if _, err := EnvTokenProvider("AIDR_TEST_TOKEN_UNSET").Token(context.Background()); err == nil {
+		t.Fatal("expected an error for an unset variable")
+	}
+}
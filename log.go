@@ -0,0 +1,70 @@
+package aidr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+)
+
+// MaxLogBatchSize is the largest number of events the API accepts in one batch.
+// [SplitLogBatch] splits larger batches.
+const MaxLogBatchSize = 100
+
+// A single free-form event, as described by the `aidr-log` schema. The spec
+// publishes no routes for logs, so the SDK has no service for them.
+//
+// The property Event is required.
+type LogParams struct {
+	// A free-form event, with at least one property.
+	Event map[string]any `json:"event,omitzero,required"`
+	paramObj
+}
+
+func (r LogParams) MarshalJSON() (data []byte, err error) {
+	type shadow LogParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *LogParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A batch of free-form events, as described by the `aidr-logs` schema. A batch
+// holds from 1 to [MaxLogBatchSize] events, see [SplitLogBatch].
+//
+// The property Events is required.
+type LogBatchParams struct {
+	// Free-form events, each with at least one property.
+	Events []map[string]any `json:"events,omitzero,required"`
+	paramObj
+}
+
+func (r LogBatchParams) MarshalJSON() (data []byte, err error) {
+	type shadow LogBatchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *LogBatchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// SplitLogBatch validates events and splits them, in order, into batches of at
+// most [MaxLogBatchSize] events. The batches share the backing array of events.
+func SplitLogBatch(events []map[string]any) ([]LogBatchParams, error) {
+	if len(events) == 0 {
+		return nil, errors.New("aidr: log batch must have at least one event")
+	}
+	for i, event := range events {
+		if len(event) == 0 {
+			return nil, fmt.Errorf("aidr: log event %d must have at least one property", i)
+		}
+	}
+	batches := make([]LogBatchParams, 0, (len(events)+MaxLogBatchSize-1)/MaxLogBatchSize)
+	for start := 0; start < len(events); start += MaxLogBatchSize {
+		end := min(start+MaxLogBatchSize, len(events))
+		batches = append(batches, LogBatchParams{Events: events[start:end:end]})
+	}
+	return batches, nil
+}
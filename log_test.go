@@ -0,0 +1,126 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestSplitLogBatch(t *testing.T) {
+	events := make([]map[string]any, 250)
+	for i := range events {
+		events[i] = map[string]any{"request_count": i}
+	}
+	batches, err := aidr.SplitLogBatch(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(batches) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(batches))
+	}
+	for i, size := range []int{100, 100, 50} {
+		body := marshalObject(t, batches[i])
+		batch := body["events"].([]any)
+		if len(batch) != size {
+			t.Errorf("batch %d: unexpected size %d", i, len(batch))
+		}
+		if first := batch[0].(map[string]any)["request_count"]; first != float64(i*100) {
+			t.Errorf("batch %d starts with event %v", i, first)
+		}
+	}
+	_ = append(batches[0].Events, map[string]any{"extra": true})
+	if events[100]["request_count"] != 100 {
+		t.Error("appending to a batch should not overwrite the next one")
+	}
+}
+
+func TestSplitLogBatchValidation(t *testing.T) {
+	if _, err := aidr.SplitLogBatch(nil); err == nil {
+		t.Error("expected an error for an empty batch")
+	}
+	_, err := aidr.SplitLogBatch([]map[string]any{{"a": 1}, {}})
+	if err == nil || !strings.Contains(err.Error(), "event 1") {
+		t.Errorf("expected an error for the empty event, got %v", err)
+	}
+
+	body := marshalObject(t, aidr.LogParams{Event: map[string]any{"active": true}})
+	if body["event"].(map[string]any)["active"] != true {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestOTelResourceLogs(t *testing.T) {
+	ts := time.Unix(1700000000, 5)
+	resource := marshalObject(t, aidr.OTelResourceLogsParam{
+		Resource: aidr.OTelResourceParam{
+			Attributes: []aidr.OTelKeyValueParam{aidr.OTelAttribute("service.name", aidr.OTelString("chat"))},
+		},
+		ScopeLogs: []aidr.OTelScopeLogsParam{{
+			Scope: aidr.OTelInstrumentationScopeParam{Name: aidr.String("aidr")},
+			LogRecords: []aidr.OTelLogRecordParam{{
+				TimeUnixNano: aidr.OTelTime(ts),
+				SeverityText: aidr.String("INFO"),
+				Body: aidr.OTelKeyValueList(
+					aidr.OTelAttribute("blocked", aidr.OTelBool(true)),
+					aidr.OTelAttribute("tags", aidr.OTelArray(aidr.OTelInt(1), aidr.OTelDouble(0.5))),
+				),
+			}},
+		}},
+	})
+
+	attr := resource["resource"].(map[string]any)["attributes"].([]any)[0].(map[string]any)
+	if attr["key"] != "service.name" || attr["value"].(map[string]any)["stringValue"] != "chat" {
+		t.Errorf("unexpected resource attribute %v", attr)
+	}
+	record := resource["scopeLogs"].([]any)[0].(map[string]any)["logRecords"].([]any)[0].(map[string]any)
+	if record["timeUnixNano"] != "1700000000000000005" || record["severityText"] != "INFO" {
+		t.Errorf("unexpected record %v", record)
+	}
+	values := record["body"].(map[string]any)["kvlistValue"].(map[string]any)["values"].([]any)
+	tags := values[1].(map[string]any)["value"].(map[string]any)["arrayValue"].(map[string]any)["values"].([]any)
+	if values[0].(map[string]any)["value"].(map[string]any)["boolValue"] != true || tags[0].(map[string]any)["intValue"] != float64(1) || tags[1].(map[string]any)["doubleValue"] != 0.5 {
+		t.Errorf("unexpected body %v", record["body"])
+	}
+}
+
+func TestOTelAnyValue(t *testing.T) {
+	cases := map[string]struct {
+		value aidr.OTelAnyValueParam
+		want  string
+	}{
+		"bytes":  {aidr.OTelBytes([]byte("hello")), `{"bytesValue":"aGVsbG8="}`},
+		"false":  {aidr.OTelBool(false), `{"boolValue":false}`},
+		"empty":  {aidr.OTelString(""), `{"stringValue":""}`},
+		"array":  {aidr.OTelArray(), `{"arrayValue":{"values":[]}}`},
+		"kvlist": {aidr.OTelKeyValueList(aidr.OTelAttribute("n", aidr.OTelInt(0))), `{"kvlistValue":{"values":[{"key":"n","value":{"intValue":0}}]}}`},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(c.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != c.want {
+				t.Errorf("expected %s, received %s", c.want, data)
+			}
+		})
+	}
+
+	var value aidr.OTelAnyValueParam
+	if err := json.Unmarshal([]byte(`{"bytesValue":"aGVsbG8="}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.OfBytes == nil || string(value.OfBytes.BytesValue) != "hello" {
+		t.Errorf("unexpected value %+v", value)
+	}
+	if err := json.Unmarshal([]byte(`{"boolValue":true}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.OfBool == nil || !value.OfBool.BoolValue || value.OfBytes != nil {
+		t.Errorf("unexpected value %+v", value)
+	}
+}
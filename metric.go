@@ -0,0 +1,410 @@
+package aidr
+
+import (
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// The result of a metric search, as described by the `aidr-metric-result`
+// schema. The spec publishes no routes for metrics, so the SDK has no service
+// for them. See [MetricSearchResult.TimeSeries] to graph the result.
+type MetricSearchResult struct {
+	Items []MetricItem `json:"items"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Items       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricSearchResult) RawJSON() string { return r.JSON.raw }
+
+// The API specification describes items as a list of lists of rows. Nested
+// lists are flattened, so both shapes decode to the same rows.
+func (r *MetricSearchResult) UnmarshalJSON(data []byte) error {
+	if err := apijson.UnmarshalRoot(flattenMetricItems(data), r); err != nil {
+		return err
+	}
+	r.JSON.raw = string(data)
+	return nil
+}
+
+// A row of a metric search, as described by the items of the `aidr-metric-item`
+// schema.
+type MetricItem struct {
+	Count int64 `json:"count,required"`
+	// Per-detector aggregated stats, keyed by detector.
+	Detectors          map[string]MetricDetectorStats `json:"detectors,required"`
+	DetectorsCount     int64                          `json:"detectors_count,required"`
+	IsBlocked          bool                           `json:"is_blocked,required"`
+	RequestTokenCount  int64                          `json:"request_token_count,required"`
+	ResponseTokenCount int64                          `json:"response_token_count,required"`
+	// Bucketed time, zero if the query has no interval.
+	BucketTime time.Time `json:"bucket_time,nullable" format:"date-time"`
+	// Map of tag keys to values.
+	Tags map[string]string `json:"tags"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count              respjson.Field
+		Detectors          respjson.Field
+		DetectorsCount     respjson.Field
+		IsBlocked          respjson.Field
+		RequestTokenCount  respjson.Field
+		ResponseTokenCount respjson.Field
+		BucketTime         respjson.Field
+		Tags               respjson.Field
+		ExtraFields        map[string]respjson.Field
+		raw                string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricItem) RawJSON() string { return r.JSON.raw }
+
+func (r *MetricItem) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Per-detector aggregated stats, as described by the
+// `aidr-metric-result-detector-item` schema.
+type MetricDetectorStats struct {
+	// Total occurrences for this detector key.
+	Count int64 `json:"count,required"`
+	// Occurrences that were flagged/detected.
+	DetectedCount int64 `json:"detected_count,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count         respjson.Field
+		DetectedCount respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricDetectorStats) RawJSON() string { return r.JSON.raw }
+
+func (r *MetricDetectorStats) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The result of a metric aggregates search, as described by the
+// `aidr-metric-aggregates-result` schema.
+type MetricAggregatesResult struct {
+	Items []MetricAggregateItem `json:"items"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Items       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricAggregatesResult) RawJSON() string { return r.JSON.raw }
+
+// The API specification describes items as a list of lists of rows. Nested
+// lists are flattened, so both shapes decode to the same rows.
+func (r *MetricAggregatesResult) UnmarshalJSON(data []byte) error {
+	if err := apijson.UnmarshalRoot(flattenMetricItems(data), r); err != nil {
+		return err
+	}
+	r.JSON.raw = string(data)
+	return nil
+}
+
+// A row of a metric aggregates search, as described by the items of the
+// `aidr-metric-aggregate-item` schema.
+type MetricAggregateItem struct {
+	// Map of tag keys to unique count.
+	Counts map[string]int64 `json:"counts,required"`
+	// Bucketed time, zero if the query has no interval.
+	BucketTime time.Time `json:"bucket_time,nullable" format:"date-time"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Counts      respjson.Field
+		BucketTime  respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricAggregateItem) RawJSON() string { return r.JSON.raw }
+
+func (r *MetricAggregateItem) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// flattenMetricItems rewrites "items": [[a, b], [c]] as "items": [a, b, c].
+func flattenMetricItems(data []byte) []byte {
+	items := gjson.GetBytes(data, "items")
+	if !items.IsArray() {
+		return data
+	}
+	var rows []string
+	nested := false
+	for _, item := range items.Array() {
+		if !item.IsArray() {
+			rows = append(rows, item.Raw)
+			continue
+		}
+		nested = true
+		for _, row := range item.Array() {
+			rows = append(rows, row.Raw)
+		}
+	}
+	if !nested {
+		return data
+	}
+	flattened, err := sjson.SetRawBytes(data, "items", []byte("["+strings.Join(rows, ",")+"]"))
+	if err != nil {
+		return data
+	}
+	return flattened
+}
+
+// A metric search, as described by the `aidr-metric` schema.
+//
+// The property StartTime is required.
+type MetricSearchParams struct {
+	// start of the query window
+	StartTime time.Time `json:"start_time,required" format:"date-time"`
+	// end of the query window, if not specified then current time is used as
+	// end_time
+	EndTime param.Opt[time.Time] `json:"end_time,omitzero" format:"date-time"`
+	// field to sort by
+	OrderBy param.Opt[string] `json:"order_by,omitzero"`
+	Limit   param.Opt[int64]  `json:"limit,omitzero"`
+	Offset  param.Opt[int64]  `json:"offset,omitzero"`
+	// Bucket size for time‐series aggregation
+	//
+	// Any of "hourly", "daily", "weekly", "monthly", "yearly".
+	Interval MetricInterval `json:"interval,omitzero"`
+	// Optional filters for the field. For example `<field>__gte` or `<field>__lt`
+	Filters MetricFilters `json:"filters,omitzero"`
+	// Optional tag filters of the tag fields. For example `<field>__contains` or
+	// `<field>__in`
+	TagFilters MetricTagFilters `json:"tag_filters,omitzero"`
+	// Per-detector filters.
+	DetectorFilters MetricDetectorFilters `json:"detector_filters,omitzero"`
+	// Optional list of tag keys to group by (for bar‑chart or Sankey)
+	GroupBy []string `json:"group_by,omitzero"`
+	// Sort direction (default: asc)
+	//
+	// Any of "asc", "desc".
+	Order MetricOrder `json:"order,omitzero"`
+	paramObj
+}
+
+func (r MetricSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow MetricSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *MetricSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A metric aggregates search, as described by the
+// `aidr-metric-aggregates-search-params` schema.
+//
+// The property StartTime is required.
+type MetricAggregatesSearchParams struct {
+	// start of the query window
+	StartTime time.Time `json:"start_time,required" format:"date-time"`
+	// end of the query window, if not specified then current time is used as
+	// end_time
+	EndTime param.Opt[time.Time] `json:"end_time,omitzero" format:"date-time"`
+	// field to sort by
+	OrderBy param.Opt[string] `json:"order_by,omitzero"`
+	Limit   param.Opt[int64]  `json:"limit,omitzero"`
+	Offset  param.Opt[int64]  `json:"offset,omitzero"`
+	// list of tag keys to aggregate
+	AggregateFields []string `json:"aggregate_fields,omitzero"`
+	// Bucket size for time‐series aggregation
+	//
+	// Any of "hourly", "daily", "weekly", "monthly", "yearly".
+	Interval MetricInterval `json:"interval,omitzero"`
+	// Optional filters for the field. For example `<field>__gte` or `<field>__lt`
+	Filters MetricFilters `json:"filters,omitzero"`
+	// Optional tag filters of the tag fields. For example `<field>__contains` or
+	// `<field>__in`
+	TagFilters MetricTagFilters `json:"tag_filters,omitzero"`
+	// Per-detector filters.
+	DetectorFilters MetricDetectorFilters `json:"detector_filters,omitzero"`
+	// Optional list of tag keys to group by (for bar‑chart or Sankey)
+	GroupBy []string `json:"group_by,omitzero"`
+	// Sort direction (default: asc)
+	//
+	// Any of "asc", "desc".
+	Order MetricOrder `json:"order,omitzero"`
+	paramObj
+}
+
+func (r MetricAggregatesSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow MetricAggregatesSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *MetricAggregatesSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Bucket size for time‐series aggregation
+type MetricInterval string
+
+const (
+	MetricIntervalHourly  MetricInterval = "hourly"
+	MetricIntervalDaily   MetricInterval = "daily"
+	MetricIntervalWeekly  MetricInterval = "weekly"
+	MetricIntervalMonthly MetricInterval = "monthly"
+	MetricIntervalYearly  MetricInterval = "yearly"
+)
+
+// Sort direction (default: asc)
+type MetricOrder string
+
+const (
+	MetricOrderAsc  MetricOrder = "asc"
+	MetricOrderDesc MetricOrder = "desc"
+)
+
+// MetricFilters holds the numeric and boolean filters of a metric query. Build it
+// by chaining its methods:
+//
+//	aidr.MetricFilters{}.Is("is_blocked", true).Gte("request_token_count", 1000)
+type MetricFilters map[string]any
+
+// Is keeps the records where field equals value.
+func (f MetricFilters) Is(field string, value bool) MetricFilters {
+	return f.set(field, value)
+}
+
+// IsNot keeps the records where field does not equal value.
+func (f MetricFilters) IsNot(field string, value bool) MetricFilters {
+	return f.set(field+"__neq", value)
+}
+
+// Eq keeps the records where field equals value.
+func (f MetricFilters) Eq(field string, value int64) MetricFilters {
+	return f.set(field+"__eq", value)
+}
+
+// Neq keeps the records where field does not equal value.
+func (f MetricFilters) Neq(field string, value int64) MetricFilters {
+	return f.set(field+"__neq", value)
+}
+
+// Gt keeps the records where field is greater than value.
+func (f MetricFilters) Gt(field string, value int64) MetricFilters {
+	return f.set(field+"__gt", value)
+}
+
+// Gte keeps the records where field is greater than or equal to value.
+func (f MetricFilters) Gte(field string, value int64) MetricFilters {
+	return f.set(field+"__gte", value)
+}
+
+// Lt keeps the records where field is less than value.
+func (f MetricFilters) Lt(field string, value int64) MetricFilters {
+	return f.set(field+"__lt", value)
+}
+
+// Lte keeps the records where field is less than or equal to value.
+func (f MetricFilters) Lte(field string, value int64) MetricFilters {
+	return f.set(field+"__lte", value)
+}
+
+func (f MetricFilters) set(key string, value any) MetricFilters {
+	if f == nil {
+		f = MetricFilters{}
+	}
+	f[key] = value
+	return f
+}
+
+// MetricTagFilters holds the tag filters of a metric query. Build it by chaining
+// its methods:
+//
+//	aidr.MetricTagFilters{}.In("app_id", "chat", "search").NotIn("model", "gpt-3.5")
+type MetricTagFilters map[string][]string
+
+// Equals keeps the records where tag equals one of values.
+func (f MetricTagFilters) Equals(tag string, values ...string) MetricTagFilters {
+	return f.set(tag, values)
+}
+
+// Contains keeps the records where tag includes each of values.
+func (f MetricTagFilters) Contains(tag string, values ...string) MetricTagFilters {
+	return f.set(tag+"__contains", values)
+}
+
+// In keeps the records where tag equals one of values.
+func (f MetricTagFilters) In(tag string, values ...string) MetricTagFilters {
+	return f.set(tag+"__in", values)
+}
+
+// NotIn keeps the records where tag equals none of values.
+func (f MetricTagFilters) NotIn(tag string, values ...string) MetricTagFilters {
+	return f.set(tag+"__not_in", values)
+}
+
+func (f MetricTagFilters) set(key string, values []string) MetricTagFilters {
+	if f == nil {
+		f = MetricTagFilters{}
+	}
+	f[key] = values
+	return f
+}
+
+// MetricDetectorFilters holds the per-detector filters of a metric query. Build
+// it by chaining its methods:
+//
+//	aidr.MetricDetectorFilters{}.
+//		Exists("prompt_injection", true).
+//		DetectedCount("gibberish", aidr.MetricComparisonGt, 5)
+type MetricDetectorFilters map[string]any
+
+// Exists keeps the records where the detector did, or did not, run.
+func (f MetricDetectorFilters) Exists(detector string, exists bool) MetricDetectorFilters {
+	return f.set(detector+"__exists", exists)
+}
+
+// Count compares the total occurrences of the detector with value.
+func (f MetricDetectorFilters) Count(detector string, op MetricComparison, value int64) MetricDetectorFilters {
+	return f.set(detector+".count__"+string(op), value)
+}
+
+// DetectedCount compares the flagged occurrences of the detector with value.
+func (f MetricDetectorFilters) DetectedCount(detector string, op MetricComparison, value int64) MetricDetectorFilters {
+	return f.set(detector+".detected_count__"+string(op), value)
+}
+
+func (f MetricDetectorFilters) set(key string, value any) MetricDetectorFilters {
+	if f == nil {
+		f = MetricDetectorFilters{}
+	}
+	f[key] = value
+	return f
+}
+
+// A numeric comparison of a per-detector filter.
+type MetricComparison string
+
+const (
+	MetricComparisonEq  MetricComparison = "eq"
+	MetricComparisonNeq MetricComparison = "neq"
+	MetricComparisonGt  MetricComparison = "gt"
+	MetricComparisonGte MetricComparison = "gte"
+	MetricComparisonLt  MetricComparison = "lt"
+	MetricComparisonLte MetricComparison = "lte"
+)
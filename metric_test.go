@@ -0,0 +1,108 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestMetricSearchParams(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	body := marshalObject(t, aidr.MetricSearchParams{
+		StartTime:  start,
+		Interval:   aidr.MetricIntervalDaily,
+		Filters:    aidr.MetricFilters{}.Is("is_blocked", true).Gte("request_token_count", 100),
+		TagFilters: aidr.MetricTagFilters{}.In("app_id", "chat", "search").NotIn("model", "legacy"),
+		DetectorFilters: aidr.MetricDetectorFilters{}.
+			Exists("prompt_injection", true).
+			DetectedCount("gibberish", aidr.MetricComparisonGt, 5),
+		GroupBy: []string{"app_id"},
+	})
+
+	if body["start_time"] != "2025-03-01T00:00:00Z" || body["interval"] != "daily" {
+		t.Errorf("unexpected body %v", body)
+	}
+	filters := body["filters"].(map[string]any)
+	if filters["is_blocked"] != true || filters["request_token_count__gte"] != float64(100) {
+		t.Errorf("unexpected filters %v", filters)
+	}
+	tags := body["tag_filters"].(map[string]any)
+	if tags["app_id__in"].([]any)[1] != "search" || tags["model__not_in"].([]any)[0] != "legacy" {
+		t.Errorf("unexpected tag filters %v", tags)
+	}
+	detectors := body["detector_filters"].(map[string]any)
+	if detectors["prompt_injection__exists"] != true || detectors["gibberish.detected_count__gt"] != float64(5) {
+		t.Errorf("unexpected detector filters %v", detectors)
+	}
+	if _, ok := body["end_time"]; ok {
+		t.Errorf("end_time should be omitted")
+	}
+}
+
+func TestMetricTimeSeries(t *testing.T) {
+	// Items are nested as described by the API specification.
+	data := `{"items": [[
+		{"bucket_time": "2025-03-02T00:00:00Z", "tags": {"app_id": "chat"}, "count": 6, "is_blocked": false, "detectors_count": 1, "request_token_count": 60, "response_token_count": 90, "detectors": {"prompt_injection": {"count": 6, "detected_count": 0}}},
+		{"bucket_time": "2025-03-01T00:00:00Z", "tags": {"app_id": "chat"}, "count": 3, "is_blocked": true, "detectors_count": 1, "request_token_count": 30, "response_token_count": 0, "detectors": {"prompt_injection": {"count": 3, "detected_count": 3}}},
+		{"bucket_time": "2025-03-01T00:00:00Z", "tags": {"app_id": "chat"}, "count": 1, "is_blocked": false, "detectors_count": 1, "request_token_count": 10, "response_token_count": 20, "detectors": {"prompt_injection": {"count": 1, "detected_count": 0}}},
+		{"bucket_time": null, "tags": {"model": "gpt", "app_id": "search"}, "count": 4, "is_blocked": false, "detectors_count": 0, "request_token_count": 0, "response_token_count": 0, "detectors": {}}
+	]]}`
+	var res aidr.MetricSearchResult
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 4 {
+		t.Fatalf("expected the nested items to be flattened, got %d", len(res.Items))
+	}
+	if res.RawJSON() != data {
+		t.Errorf("expected the unmodified JSON, got %s", res.RawJSON())
+	}
+
+	series := res.TimeSeries()
+	chat := series["app_id=chat"]
+	if chat == nil || len(chat.Points) != 2 {
+		t.Fatalf("unexpected series %+v", series)
+	}
+	first := chat.Points[0]
+	if !first.Time.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) || first.Count != 4 || first.Blocked != 3 {
+		t.Errorf("unexpected point %+v", first)
+	}
+	if first.BlockRate() != 0.75 || chat.Points[1].BlockRate() != 0 {
+		t.Errorf("unexpected block rates %v, %v", first.BlockRate(), chat.Points[1].BlockRate())
+	}
+	if stats := first.Detectors["prompt_injection"]; stats.Count != 4 || stats.DetectedCount != 3 {
+		t.Errorf("unexpected detector stats %+v", stats)
+	}
+
+	search := series["app_id=search,model=gpt"]
+	if search == nil || !search.Points[0].Time.IsZero() || search.Tags["model"] != "gpt" {
+		t.Errorf("unexpected series %+v", search)
+	}
+}
+
+func TestMetricAggregatesTimeSeries(t *testing.T) {
+	body := marshalObject(t, aidr.MetricAggregatesSearchParams{
+		StartTime:       time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		AggregateFields: []string{"app_id", "actor_id"},
+	})
+	if body["aggregate_fields"].([]any)[1] != "actor_id" {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var res aidr.MetricAggregatesResult
+	err := json.Unmarshal([]byte(`{"items": [
+		{"bucket_time": "2025-03-02T00:00:00Z", "counts": {"app_id": 2, "actor_id": 9}},
+		{"bucket_time": "2025-03-01T00:00:00Z", "counts": {"app_id": 3}}
+	]}`), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	series := res.TimeSeries()
+	apps := series["app_id"]
+	if len(apps) != 2 || apps[0].Count != 3 || apps[1].Count != 2 || len(series["actor_id"]) != 1 {
+		t.Errorf("unexpected series %+v", series)
+	}
+}
@@ -0,0 +1,234 @@
+package aidr
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A metric pool, as described by the `aidr-metricpool-resource` schema. The
+// spec publishes no routes for metric pools, so the SDK has no service for
+// them.
+type MetricPool struct {
+	// A metric pool ID
+	ID string `json:"id"`
+	// Define field name and path mapping to extract from the log
+	FieldMappings map[string]MetricPoolFieldMapping `json:"field_mappings"`
+	// A time in ISO-8601 format
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID            respjson.Field
+		FieldMappings respjson.Field
+		UpdatedAt     respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricPool) RawJSON() string { return r.JSON.raw }
+
+func (r *MetricPool) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// FieldMappingParams returns the field mappings of the metric pool as params, so
+// that they can be edited and sent back in a [MetricPoolParams].
+func (r MetricPool) FieldMappingParams() map[string]MetricPoolFieldMappingParam {
+	res := make(map[string]MetricPoolFieldMappingParam, len(r.FieldMappings))
+	for field, mapping := range r.FieldMappings {
+		res[field] = mapping.ToParam()
+	}
+	return res
+}
+
+// A field mapping, as described by the values of the
+// `aidr-resource-field-mapping` schema.
+type MetricPoolFieldMapping struct {
+	// The path of the field in the log, such as "user.name" or
+	// "events.#(type==prompt).text".
+	Path string `json:"path,required"`
+	// Any of "string", "int", "bool".
+	Type     MetricPoolFieldMappingType `json:"type,required"`
+	Disabled bool                       `json:"disabled"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Path        respjson.Field
+		Type        respjson.Field
+		Disabled    respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r MetricPoolFieldMapping) RawJSON() string { return r.JSON.raw }
+
+func (r *MetricPoolFieldMapping) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this MetricPoolFieldMapping to a MetricPoolFieldMappingParam
+// whose fields are set, so that it can be edited before an update.
+func (r MetricPoolFieldMapping) ToParam() MetricPoolFieldMappingParam {
+	p := MetricPoolFieldMappingParam{Path: r.Path, Type: r.Type}
+	if r.JSON.Disabled.Valid() {
+		p.Disabled = param.NewOpt(r.Disabled)
+	}
+	return p
+}
+
+type MetricPoolFieldMappingType string
+
+const (
+	MetricPoolFieldMappingTypeString MetricPoolFieldMappingType = "string"
+	MetricPoolFieldMappingTypeInt    MetricPoolFieldMappingType = "int"
+	MetricPoolFieldMappingTypeBool   MetricPoolFieldMappingType = "bool"
+)
+
+// The field mappings of a metric pool, as described by the
+// `aidr-metricpool-resource` schema. Check them with [MetricPoolParams.Validate]
+// before sending them.
+//
+// The property FieldMappings is required.
+type MetricPoolParams struct {
+	// A metric pool ID
+	ID param.Opt[string] `json:"id,omitzero"`
+	// Define field name and path mapping to extract from the log
+	FieldMappings map[string]MetricPoolFieldMappingParam `json:"field_mappings,omitzero,required"`
+	paramObj
+}
+
+func (r MetricPoolParams) MarshalJSON() (data []byte, err error) {
+	type shadow MetricPoolParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *MetricPoolParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Validate checks every field mapping against the constraints of the API. The
+// returned error is a [FieldMappingErrors], with one entry per invalid mapping,
+// sorted by field name.
+func (r MetricPoolParams) Validate() error {
+	var errs FieldMappingErrors
+	for _, field := range slices.Sorted(maps.Keys(r.FieldMappings)) {
+		mapping := r.FieldMappings[field]
+		if reason := validateFieldMapping(field, mapping); reason != "" {
+			errs = append(errs, FieldMappingError{Field: field, Path: mapping.Path, Reason: reason})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// A field mapping, as described by the values of the
+// `aidr-resource-field-mapping` schema.
+//
+// The properties Path, Type are required.
+type MetricPoolFieldMappingParam struct {
+	// The path of the field in the log, such as "user.name" or
+	// "events.#(type==prompt).text".
+	Path string `json:"path,required"`
+	// Any of "string", "int", "bool".
+	Type     MetricPoolFieldMappingType `json:"type,omitzero,required"`
+	Disabled param.Opt[bool]            `json:"disabled,omitzero"`
+	paramObj
+}
+
+func (r MetricPoolFieldMappingParam) MarshalJSON() (data []byte, err error) {
+	type shadow MetricPoolFieldMappingParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *MetricPoolFieldMappingParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// FieldMappingError describes an invalid field mapping.
+type FieldMappingError struct {
+	// Field is the name of the mapped field.
+	Field string
+	// Path is the invalid path, as given.
+	Path string
+	// Reason tells what is wrong with the mapping.
+	Reason string
+}
+
+func (e FieldMappingError) Error() string {
+	return fmt.Sprintf("field mapping %q: %s", e.Field, e.Reason)
+}
+
+// FieldMappingErrors lists the invalid mappings of a [MetricPoolParams], as
+// returned by [MetricPoolParams.Validate].
+type FieldMappingErrors []FieldMappingError
+
+func (e FieldMappingErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "aidr: invalid field mappings: " + strings.Join(msgs, "; ")
+}
+
+var (
+	// The pattern of a mapping path in the `aidr-resource-field-mapping` schema.
+	fieldMappingPathPattern = regexp.MustCompile(`^([\w]+|\*)(\.(\*|[\w]+|\#(\(\w+(==|!=|=~|>|<)[^)]*\))?|\d+))*$`)
+	fieldMappingHeadPattern = regexp.MustCompile(`^([\w]+|\*)$`)
+	fieldMappingStepPattern = regexp.MustCompile(`^(\*|[\w]+|\#(\(\w+(==|!=|=~|>|<)[^)]*\))?|\d+)$`)
+)
+
+func validateFieldMapping(field string, mapping MetricPoolFieldMappingParam) string {
+	switch {
+	case field == "":
+		return "field name is empty"
+	case mapping.Path == "":
+		return "path is empty"
+	case !slices.Contains([]MetricPoolFieldMappingType{MetricPoolFieldMappingTypeString, MetricPoolFieldMappingTypeInt, MetricPoolFieldMappingTypeBool}, mapping.Type):
+		return fmt.Sprintf("type %q is not one of string, int, bool", mapping.Type)
+	case fieldMappingPathPattern.MatchString(mapping.Path):
+		return ""
+	}
+
+	segments := splitFieldMappingPath(mapping.Path)
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			return fmt.Sprintf("path %q has an empty segment at position %d", mapping.Path, i+1)
+		case i == 0 && !fieldMappingHeadPattern.MatchString(segment):
+			return fmt.Sprintf("path %q must start with a name or *, not %q", mapping.Path, segment)
+		case i > 0 && !fieldMappingStepPattern.MatchString(segment):
+			return fmt.Sprintf("path %q has an invalid segment %q at position %d; expected a name, *, an index or a #(key==value) query", mapping.Path, segment, i+1)
+		}
+	}
+	return fmt.Sprintf("path %q does not match %s", mapping.Path, fieldMappingPathPattern)
+}
+
+// splitFieldMappingPath splits path on the dots outside of #(...) queries.
+func splitFieldMappingPath(path string) []string {
+	var segments []string
+	depth, start := 0, 0
+	for i, c := range path {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == '.' && depth == 0:
+			segments = append(segments, path[start:i])
+			start = i + 1
+		}
+	}
+	return append(segments, path[start:])
+}
@@ -0,0 +1,82 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestMetricPoolFieldMappings(t *testing.T) {
+	var pool aidr.MetricPool
+	err := json.Unmarshal([]byte(`{
+		"id": "pro_xpkhwpnz2cmegsws737xbsqnmnuwtbm5",
+		"updated_at": "2025-01-01T00:00:00Z",
+		"field_mappings": {
+			"user": {"path": "user.name", "type": "string"},
+			"tokens": {"path": "usage.#(kind==prompt).count", "type": "int", "disabled": true}
+		}
+	}`), &pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := pool.FieldMappings["tokens"]; m.Type != aidr.MetricPoolFieldMappingTypeInt || !m.Disabled {
+		t.Errorf("unexpected mapping %+v", m)
+	}
+
+	mappings := pool.FieldMappingParams()
+	mappings["blocked"] = aidr.MetricPoolFieldMappingParam{Path: "result.blocked", Type: aidr.MetricPoolFieldMappingTypeBool}
+	params := aidr.MetricPoolParams{ID: aidr.String(pool.ID), FieldMappings: mappings}
+	if err := params.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	body := marshalObject(t, params)
+	sent := body["field_mappings"].(map[string]any)
+	if body["id"] != pool.ID || len(sent) != 3 {
+		t.Errorf("unexpected body %v", body)
+	}
+	if tokens := sent["tokens"].(map[string]any); tokens["disabled"] != true || tokens["path"] != "usage.#(kind==prompt).count" {
+		t.Errorf("unexpected mapping %v", tokens)
+	}
+	if _, ok := sent["user"].(map[string]any)["disabled"]; ok {
+		t.Errorf("disabled should be omitted when absent")
+	}
+}
+
+func TestMetricPoolValidation(t *testing.T) {
+	err := aidr.MetricPoolParams{
+		FieldMappings: map[string]aidr.MetricPoolFieldMappingParam{
+			"ok":    {Path: "a.*.0.#", Type: aidr.MetricPoolFieldMappingTypeString},
+			"dots":  {Path: "a..b", Type: aidr.MetricPoolFieldMappingTypeString},
+			"dash":  {Path: "a.b-c", Type: aidr.MetricPoolFieldMappingTypeString},
+			"head":  {Path: "#.a", Type: aidr.MetricPoolFieldMappingTypeString},
+			"query": {Path: "a.#(x==1.5).b", Type: aidr.MetricPoolFieldMappingTypeInt},
+			"type":  {Path: "a", Type: "float"},
+		},
+	}.Validate()
+	var errs aidr.FieldMappingErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected FieldMappingErrors, got %v", err)
+	}
+
+	want := map[string]string{
+		"dash": `invalid segment "b-c" at position 2`,
+		"dots": "empty segment at position 2",
+		"head": `must start with a name or *, not "#"`,
+		"type": `type "float"`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	for _, e := range errs {
+		if !strings.Contains(e.Reason, want[e.Field]) {
+			t.Errorf("%s: unexpected reason %q", e.Field, e.Reason)
+		}
+	}
+	if errs[0].Field != "dash" || errs[0].Path != "a.b-c" {
+		t.Errorf("expected errors sorted by field, got %v", errs)
+	}
+}
@@ -0,0 +1,116 @@
+package aidr
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MetricSeries is the time series of the metric rows sharing one tag set.
+type MetricSeries struct {
+	// Key identifies the tag set, see [MetricTagSetKey].
+	Key  string
+	Tags map[string]string
+	// Points are sorted by time, with one point per bucket.
+	Points []MetricPoint
+}
+
+// MetricPoint sums the metric rows of one bucket of a [MetricSeries].
+type MetricPoint struct {
+	Time time.Time
+	// Count is the number of events in the bucket.
+	Count int64
+	// Blocked is the number of blocked events in the bucket.
+	Blocked            int64
+	DetectorsCount     int64
+	RequestTokenCount  int64
+	ResponseTokenCount int64
+	Detectors          map[string]MetricDetectorStats
+}
+
+// BlockRate returns the share of the events of the bucket that were blocked,
+// between 0 and 1.
+func (p MetricPoint) BlockRate() float64 {
+	if p.Count == 0 {
+		return 0
+	}
+	return float64(p.Blocked) / float64(p.Count)
+}
+
+// MetricTagSetKey returns a canonical key for a tag set, made of its
+// "key=value" pairs sorted by key and joined by commas.
+func MetricTagSetKey(tags map[string]string) string {
+	keys := slices.Sorted(maps.Keys(tags))
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + tags[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// TimeSeries groups the rows of the result by tag set, keyed by
+// [MetricTagSetKey]. Rows of the same tag set and bucket, such as the blocked and
+// allowed rows, are summed into one point.
+func (r MetricSearchResult) TimeSeries() map[string]*MetricSeries {
+	series := map[string]*MetricSeries{}
+	for _, item := range r.Items {
+		key := MetricTagSetKey(item.Tags)
+		s, ok := series[key]
+		if !ok {
+			s = &MetricSeries{Key: key, Tags: item.Tags}
+			series[key] = s
+		}
+
+		i, found := slices.BinarySearchFunc(s.Points, item.BucketTime, func(p MetricPoint, t time.Time) int {
+			return p.Time.Compare(t)
+		})
+		if !found {
+			s.Points = slices.Insert(s.Points, i, MetricPoint{Time: item.BucketTime})
+		}
+		s.Points[i].add(item)
+	}
+	return series
+}
+
+func (p *MetricPoint) add(item MetricItem) {
+	p.Count += item.Count
+	if item.IsBlocked {
+		p.Blocked += item.Count
+	}
+	p.DetectorsCount += item.DetectorsCount
+	p.RequestTokenCount += item.RequestTokenCount
+	p.ResponseTokenCount += item.ResponseTokenCount
+	for name, stats := range item.Detectors {
+		if p.Detectors == nil {
+			p.Detectors = map[string]MetricDetectorStats{}
+		}
+		sum := p.Detectors[name]
+		sum.Count += stats.Count
+		sum.DetectedCount += stats.DetectedCount
+		p.Detectors[name] = sum
+	}
+}
+
+// MetricCountPoint is one bucket of the unique count of a tag.
+type MetricCountPoint struct {
+	Time  time.Time
+	Count int64
+}
+
+// TimeSeries returns the unique counts of the result per tag key, each sorted by
+// time.
+func (r MetricAggregatesResult) TimeSeries() map[string][]MetricCountPoint {
+	series := map[string][]MetricCountPoint{}
+	for _, item := range r.Items {
+		for key, count := range item.Counts {
+			series[key] = append(series[key], MetricCountPoint{Time: item.BucketTime, Count: count})
+		}
+	}
+	for _, points := range series {
+		slices.SortStableFunc(points, func(a, b MetricCountPoint) int {
+			return a.Time.Compare(b.Time)
+		})
+	}
+	return series
+}
@@ -1,13 +1,24 @@
 package option
 
 import (
+	"bufio"
+	"bytes"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/requestconfig"
+	"github.com/crowdstrike/aidr-go/packages/redact"
+	"github.com/tidwall/gjson"
 )
 
 // WithDebugLog logs the HTTP request and response content.
 // If the logger parameter is nil, it uses the default logger.
+// Credentials such as the Authorization header are masked, and bodies are
+// logged as their length and SHA-256 digest so that prompts are never written
+// out.
 //
 // WithDebugLog is for debugging and development purposes only.
 // It should not be used in production code. The behavior and interface
@@ -18,19 +29,130 @@ func WithDebugLog(logger *log.Logger) RequestOption {
 			logger = log.Default()
 		}
 
-		if reqBytes, err := httputil.DumpRequest(req, true); err == nil {
-			logger.Printf("Request Content:\n%s\n", reqBytes)
+		logger.Printf("Request Content:\n%s\n", redact.DumpRequest(req, redact.Hash))
+
+		resp, err := nxt(req)
+		if err != nil {
+			return resp, err
 		}
 
+		logger.Printf("Response Content:\n%s\n", redact.DumpResponse(resp, redact.Hash))
+
+		return resp, err
+	})
+}
+
+// BodyRedaction controls how request and response bodies are rendered by
+// [WithLogger] and by the redacted dumps of API errors.
+type BodyRedaction = redact.Mode
+
+const (
+	// BodyRedactionOmit leaves bodies out entirely.
+	BodyRedactionOmit BodyRedaction = redact.Omit
+	// BodyRedactionHash replaces bodies with their length and SHA-256 digest.
+	BodyRedactionHash BodyRedaction = redact.Hash
+	// BodyRedactionTruncate keeps only the beginning of bodies.
+	BodyRedactionTruncate BodyRedaction = redact.Truncate
+	// BodyRedactionFull logs bodies unchanged, including prompts.
+	BodyRedactionFull BodyRedaction = redact.Full
+)
+
+// LoggerOptions configures [WithLogger].
+type LoggerOptions struct {
+	// Body controls how request and response bodies are logged. Bodies are
+	// omitted by default.
+	Body BodyRedaction
+	// TruncateAt is the number of bytes kept with [BodyRedactionTruncate].
+	// Defaults to 256.
+	TruncateAt int
+	// Level is the level of the log records. Defaults to [slog.LevelInfo].
+	Level slog.Level
+}
+
+// WithLogger returns a RequestOption that writes one structured record to logger
+// per HTTP attempt, with the method, path, attempt, status, latency and
+// request_id attributes. Credentials are never logged, and bodies are only
+// logged as allowed by opts.Body.
+//
+// If the logger parameter is nil, it uses [slog.Default] at the time of each
+// request.
+func WithLogger(logger *slog.Logger, opts LoggerOptions) RequestOption {
+	return WithMiddleware(func(req *http.Request, nxt MiddlewareNext) (*http.Response, error) {
+		logger := logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		ctx := req.Context()
+		if !logger.Enabled(ctx, opts.Level) {
+			return nxt(req)
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Int("attempt", requestconfig.Attempt(ctx)),
+		}
+		if opts.Body != BodyRedactionOmit && req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				b, _ := io.ReadAll(body)
+				body.Close()
+				attrs = append(attrs, slog.String("request_body", redact.Body(b, opts.Body, opts.TruncateAt)))
+			}
+		}
+
+		start := time.Now()
 		resp, err := nxt(req)
+		attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
 		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			logger.LogAttrs(ctx, opts.Level, "aidr request failed", attrs...)
+			return resp, err
+		}
+
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if opts.Body == BodyRedactionOmit {
+			// Only the request ID is needed, which comes first in the response
+			// envelope, so peek at the beginning of the body instead of buffering
+			// all of it.
+			br := bufio.NewReaderSize(resp.Body, requestIDPeekSize)
+			b, readErr := br.Peek(requestIDPeekSize)
+			resp.Body = readCloser{br, resp.Body}
+			if readErr != nil && readErr != io.EOF {
+				attrs = append(attrs, slog.String("error", readErr.Error()))
+			}
+			if requestID := gjson.GetBytes(b, "request_id"); requestID.Exists() {
+				attrs = append(attrs, slog.String("request_id", requestID.String()))
+			}
+			logger.LogAttrs(ctx, opts.Level, "aidr request", attrs...)
 			return resp, err
 		}
 
-		if respBytes, err := httputil.DumpResponse(resp, true); err == nil {
-			logger.Printf("Response Content:\n%s\n", respBytes)
+		b, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			// Put back what was read, so that the caller still sees the rest of the
+			// body and the read error.
+			resp.Body = readCloser{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+			attrs = append(attrs, slog.String("error", readErr.Error()))
+		} else {
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(b))
+			if requestID := gjson.GetBytes(b, "request_id"); requestID.Exists() {
+				attrs = append(attrs, slog.String("request_id", requestID.String()))
+			}
+			attrs = append(attrs, slog.String("response_body", redact.Body(b, opts.Body, opts.TruncateAt)))
 		}
 
+		logger.LogAttrs(ctx, opts.Level, "aidr request", attrs...)
 		return resp, err
 	})
 }
+
+// requestIDPeekSize is how much of a response body [WithLogger] reads to find
+// the request ID when bodies are not logged.
+const requestIDPeekSize = 1024
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -2,6 +2,7 @@ package option
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,6 +27,80 @@ type RequestOption = requestconfig.RequestOption
 func WithBaseURLTemplate(baseURLTemplate string) RequestOption {
 	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
 		r.BaseURLTemplate = baseURLTemplate
+		r.Failover = nil
+		return nil
+	})
+}
+
+// Region is a regional cluster of the AIDR API, see [WithRegion].
+type Region string
+
+const (
+	// RegionAWSUS is the "Pangea regional service cluster" listed first in the
+	// servers of the OpenAPI spec.
+	RegionAWSUS Region = "aws.us"
+	// RegionAWSUSWest2 is the second "Pangea regional service cluster" listed in
+	// the servers of the OpenAPI spec.
+	RegionAWSUSWest2 Region = "aws.us-west-2"
+	// RegionEU1 is the CrowdStrike EU-1 cloud, whose template is the one
+	// documented in the README.
+	RegionEU1 Region = "eu-1"
+)
+
+var regionBaseURLTemplates = map[Region]string{
+	RegionAWSUS:      "https://aidr.aws.us.pangea.cloud",
+	RegionAWSUSWest2: "https://aidr.aws.us-west-2.pangea.cloud",
+	RegionEU1:        "https://api.eu-1.crowdstrike.com/aidr/{SERVICE_NAME}",
+}
+
+// BaseURLTemplate returns the base URL template of the region, or an empty
+// string if the region is unknown.
+func (r Region) BaseURLTemplate() string {
+	return regionBaseURLTemplates[r]
+}
+
+// WithRegion returns a RequestOption that sets the BaseURLTemplate for the
+// client to the one of a regional cluster.
+func WithRegion(region Region) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		template := region.BaseURLTemplate()
+		if template == "" {
+			return fmt.Errorf("requestoption: unknown region %q", string(region))
+		}
+		r.BaseURLTemplate = template
+		r.Failover = nil
+		return nil
+	})
+}
+
+// Failover spreads requests over an ordered list of base URL templates, see
+// [NewFailover].
+type Failover = requestconfig.Failover
+
+// FailoverEndpoint describes the health of one endpoint of a [Failover].
+type FailoverEndpoint = requestconfig.FailoverEndpoint
+
+// NewFailover returns a [Failover] over baseURLTemplates, in order of
+// preference. Requests go to the first healthy endpoint. An endpoint that fails
+// with a connection error or an HTTP/5xx response is skipped for cooldown, and
+// the request moves on to the next endpoint right away.
+//
+// Health is tracked by the returned value, so use the same one for all the
+// requests that should share it.
+func NewFailover(cooldown time.Duration, baseURLTemplates ...string) *Failover {
+	return requestconfig.NewFailover(cooldown, baseURLTemplates...)
+}
+
+// WithFailover returns a RequestOption that sends requests to the endpoints of
+// failover instead of a single BaseURLTemplate.
+//
+//	option.WithFailover(option.NewFailover(time.Minute,
+//		option.RegionAWSUS.BaseURLTemplate(),
+//		option.RegionAWSUSWest2.BaseURLTemplate(),
+//	))
+func WithFailover(failover *Failover) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.Failover = failover
 		return nil
 	})
 }
@@ -262,6 +337,7 @@ func WithRequestTimeout(dur time.Duration) RequestOption {
 func WithToken(value string) RequestOption {
 	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
 		r.Token = value
+		r.TokenProvider = nil
 		return nil
 	})
 }
@@ -270,8 +346,105 @@ func WithToken(value string) RequestOption {
 // token. Service-specific API tokens override the client-level API token when
 // present.
 func WithServiceToken(serviceName, token string) RequestOption {
+	return WithServiceTokenProvider(serviceName, StaticToken(token))
+}
+
+// TokenProvider supplies the API token before every attempt, see
+// [WithTokenProvider].
+type TokenProvider = requestconfig.TokenProvider
+
+// StaticToken is a [TokenProvider] that always returns the same token.
+type StaticToken = requestconfig.StaticToken
+
+// RefreshingTokenProvider caches the token returned by a callback until it
+// expires, see [NewRefreshingTokenProvider].
+type RefreshingTokenProvider = requestconfig.RefreshingTokenProvider
+
+// FileTokenProvider reads the token from a file and reloads it when the file
+// changed, checking it at most once per CheckInterval, see
+// [NewFileTokenProvider].
+type FileTokenProvider = requestconfig.FileTokenProvider
+
+// EnvTokenProvider reads the token from the named environment variable on
+// every attempt.
+type EnvTokenProvider = requestconfig.EnvTokenProvider
+
+// NewRefreshingTokenProvider returns a [TokenProvider] that calls fetch for a
+// token and its expiry time, and calls it again leeway before the token expires
+// or when the API rejects it. A zero expiry time means the token never expires.
+func NewRefreshingTokenProvider(fetch func(ctx context.Context) (token string, expiresAt time.Time, err error), leeway time.Duration) *RefreshingTokenProvider {
+	return requestconfig.NewRefreshingTokenProvider(fetch, leeway)
+}
+
+// NewFileTokenProvider returns a [TokenProvider] reading the token from the
+// file at path, such as a mounted secret. The file is not watched: it is
+// checked at most once per [FileTokenProvider.CheckInterval], one second by
+// default, and read again when its modification time or size changed.
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return requestconfig.NewFileTokenProvider(path)
+}
+
+// WithTokenProvider returns a RequestOption that sets the client's API token
+// provider, which is asked for a token before every attempt. If the API answers
+// with HTTP 401, the token is invalidated and the request is retried once with
+// a fresh token. Service-specific API tokens will take precedence over this.
+func WithTokenProvider(provider TokenProvider) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.TokenProvider = provider
+		return nil
+	})
+}
+
+// WithServiceTokenProvider returns a RequestOption that sets a service-specific
+// API token provider, see [WithTokenProvider]. Service-specific API tokens
+// override the client-level API token when present.
+func WithServiceTokenProvider(serviceName string, provider TokenProvider) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		if r.ServiceTokens == nil {
+			r.ServiceTokens = map[string]requestconfig.TokenProvider{}
+		}
+		r.ServiceTokens[serviceName] = provider
+		return nil
+	})
+}
+
+// RetryPolicy customizes when and how failed requests are retried, see
+// [WithRetryPolicy].
+type RetryPolicy = requestconfig.RetryPolicy
+
+// RetryEvent describes a retry that is about to happen, see [RetryPolicy].
+type RetryEvent = requestconfig.RetryEvent
+
+// Jitter is a strategy to randomize the delay between two attempts.
+type Jitter = requestconfig.Jitter
+
+const (
+	JitterProportional = requestconfig.JitterProportional
+	JitterNone         = requestconfig.JitterNone
+	JitterFull         = requestconfig.JitterFull
+	JitterEqual        = requestconfig.JitterEqual
+)
+
+// Clock abstracts time so that retries can be tested deterministically.
+type Clock = requestconfig.Clock
+
+// RetryBudget limits the number of retries made by all the requests sharing
+// it. Create one with [NewRetryBudget].
+type RetryBudget = requestconfig.RetryBudget
+
+// NewRetryBudget returns a budget allowing up to retries retries per period,
+// refilled continuously. Share it across clients or requests with
+// [RetryPolicy.Budget].
+func NewRetryBudget(retries int, per time.Duration) *RetryBudget {
+	return requestconfig.NewRetryBudget(retries, per)
+}
+
+// WithRetryPolicy returns a RequestOption that customizes which failures are
+// retried and how long to wait between attempts. The number of retries is still
+// set with [WithMaxRetries].
+func WithRetryPolicy(policy RetryPolicy) RequestOption {
 	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
-		r.ServiceTokens.Store(serviceName, token)
+		r.RetryPolicy = &policy
 		return nil
 	})
 }
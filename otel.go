@@ -0,0 +1,368 @@
+package aidr
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/tidwall/gjson"
+)
+
+// OTelString returns a string [OTelAnyValueParam].
+func OTelString(v string) OTelAnyValueParam {
+	return OTelAnyValueParam{OfString: &OTelStringValueParam{StringValue: v}}
+}
+
+// OTelBool returns a boolean [OTelAnyValueParam].
+func OTelBool(v bool) OTelAnyValueParam {
+	return OTelAnyValueParam{OfBool: &OTelBoolValueParam{BoolValue: v}}
+}
+
+// OTelInt returns an integer [OTelAnyValueParam].
+func OTelInt(v int64) OTelAnyValueParam {
+	return OTelAnyValueParam{OfInt: &OTelIntValueParam{IntValue: v}}
+}
+
+// OTelDouble returns a floating point [OTelAnyValueParam].
+func OTelDouble(v float64) OTelAnyValueParam {
+	return OTelAnyValueParam{OfDouble: &OTelDoubleValueParam{DoubleValue: v}}
+}
+
+// OTelBytes returns a bytes [OTelAnyValueParam], sent base64 encoded.
+func OTelBytes(v []byte) OTelAnyValueParam {
+	if v == nil {
+		v = []byte{}
+	}
+	return OTelAnyValueParam{OfBytes: &OTelBytesValueParam{BytesValue: v}}
+}
+
+// OTelArray returns an array [OTelAnyValueParam] of values.
+func OTelArray(values ...OTelAnyValueParam) OTelAnyValueParam {
+	if values == nil {
+		values = []OTelAnyValueParam{}
+	}
+	return OTelAnyValueParam{OfArray: &OTelArrayValueVariantParam{ArrayValue: OTelArrayValueParam{Values: values}}}
+}
+
+// OTelKeyValueList returns a key-value list [OTelAnyValueParam].
+func OTelKeyValueList(values ...OTelKeyValueParam) OTelAnyValueParam {
+	if values == nil {
+		values = []OTelKeyValueParam{}
+	}
+	return OTelAnyValueParam{OfKvlist: &OTelKvlistValueParam{KvlistValue: OTelKeyValueListParam{Values: values}}}
+}
+
+// OTelAttribute returns an [OTelKeyValueParam], as used for attributes.
+func OTelAttribute(key string, value OTelAnyValueParam) OTelKeyValueParam {
+	return OTelKeyValueParam{Key: key, Value: value}
+}
+
+// OTelTime returns t in nanoseconds since the Unix epoch, the encoding of the
+// timestamps of OTLP log records.
+func OTelTime(t time.Time) param.Opt[string] {
+	return param.NewOpt(strconv.FormatInt(t.UnixNano(), 10))
+}
+
+// The logs of a resource in the OTLP JSON encoding, as described by the
+// `aidr-otel-resource-logs` schema.
+//
+// The property ScopeLogs is required.
+type OTelResourceLogsParam struct {
+	ScopeLogs []OTelScopeLogsParam `json:"scopeLogs,omitzero,required"`
+	Resource  OTelResourceParam    `json:"resource,omitzero"`
+	paramObj
+}
+
+func (r OTelResourceLogsParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelResourceLogsParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelResourceLogsParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type OTelResourceParam struct {
+	Attributes []OTelKeyValueParam `json:"attributes,omitzero"`
+	paramObj
+}
+
+func (r OTelResourceParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelResourceParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelResourceParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property LogRecords is required.
+type OTelScopeLogsParam struct {
+	LogRecords []OTelLogRecordParam          `json:"logRecords,omitzero,required"`
+	Scope      OTelInstrumentationScopeParam `json:"scope,omitzero"`
+	paramObj
+}
+
+func (r OTelScopeLogsParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelScopeLogsParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelScopeLogsParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type OTelInstrumentationScopeParam struct {
+	Name    param.Opt[string] `json:"name,omitzero"`
+	Version param.Opt[string] `json:"version,omitzero"`
+	paramObj
+}
+
+func (r OTelInstrumentationScopeParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelInstrumentationScopeParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelInstrumentationScopeParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property Body is required.
+type OTelLogRecordParam struct {
+	Body OTelAnyValueParam `json:"body,omitzero,required"`
+	// Nanoseconds since the Unix epoch, see [OTelTime].
+	TimeUnixNano param.Opt[string] `json:"timeUnixNano,omitzero"`
+	// Nanoseconds since the Unix epoch, see [OTelTime].
+	ObservedTimeUnixNano param.Opt[string]   `json:"observedTimeUnixNano,omitzero"`
+	SeverityNumber       param.Opt[int64]    `json:"severityNumber,omitzero"`
+	SeverityText         param.Opt[string]   `json:"severityText,omitzero"`
+	Name                 param.Opt[string]   `json:"name,omitzero"`
+	Flags                param.Opt[int64]    `json:"flags,omitzero"`
+	TraceID              param.Opt[string]   `json:"traceId,omitzero"`
+	SpanID               param.Opt[string]   `json:"spanId,omitzero"`
+	TraceFlags           param.Opt[string]   `json:"traceFlags,omitzero"`
+	Attributes           []OTelKeyValueParam `json:"attributes,omitzero"`
+	paramObj
+}
+
+func (r OTelLogRecordParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelLogRecordParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelLogRecordParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The properties Key, Value are required.
+type OTelKeyValueParam struct {
+	Key   string            `json:"key,required"`
+	Value OTelAnyValueParam `json:"value,omitzero,required"`
+	paramObj
+}
+
+func (r OTelKeyValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelKeyValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelKeyValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A value, as described by the `aidr-otel-any-value` schema.
+//
+// Only one field can be non-zero. Use the constructors such as [OTelString] and
+// [OTelKeyValueList] to build a value.
+//
+// Use [param.IsOmitted] to confirm if a field is set.
+type OTelAnyValueParam struct {
+	OfString *OTelStringValueParam       `json:",omitzero,inline"`
+	OfBool   *OTelBoolValueParam         `json:",omitzero,inline"`
+	OfInt    *OTelIntValueParam          `json:",omitzero,inline"`
+	OfDouble *OTelDoubleValueParam       `json:",omitzero,inline"`
+	OfBytes  *OTelBytesValueParam        `json:",omitzero,inline"`
+	OfArray  *OTelArrayValueVariantParam `json:",omitzero,inline"`
+	OfKvlist *OTelKvlistValueParam       `json:",omitzero,inline"`
+	paramUnion
+}
+
+func (u OTelAnyValueParam) MarshalJSON() ([]byte, error) {
+	return param.MarshalUnion(u, u.OfString, u.OfBool, u.OfInt, u.OfDouble, u.OfBytes, u.OfArray, u.OfKvlist)
+}
+
+// The variants are told apart by their key, as they share no discriminator.
+func (u *OTelAnyValueParam) UnmarshalJSON(data []byte) error {
+	*u = OTelAnyValueParam{}
+	value := gjson.ParseBytes(data)
+	switch {
+	case value.Get("stringValue").Exists():
+		u.OfString = &OTelStringValueParam{}
+		return u.OfString.UnmarshalJSON(data)
+	case value.Get("boolValue").Exists():
+		u.OfBool = &OTelBoolValueParam{}
+		return u.OfBool.UnmarshalJSON(data)
+	case value.Get("intValue").Exists():
+		u.OfInt = &OTelIntValueParam{}
+		return u.OfInt.UnmarshalJSON(data)
+	case value.Get("doubleValue").Exists():
+		u.OfDouble = &OTelDoubleValueParam{}
+		return u.OfDouble.UnmarshalJSON(data)
+	case value.Get("bytesValue").Exists():
+		u.OfBytes = &OTelBytesValueParam{}
+		return u.OfBytes.UnmarshalJSON(data)
+	case value.Get("arrayValue").Exists():
+		u.OfArray = &OTelArrayValueVariantParam{}
+		return u.OfArray.UnmarshalJSON(data)
+	case value.Get("kvlistValue").Exists():
+		u.OfKvlist = &OTelKvlistValueParam{}
+		return u.OfKvlist.UnmarshalJSON(data)
+	}
+	return apijson.UnmarshalRoot(data, u)
+}
+
+// The property StringValue is required.
+type OTelStringValueParam struct {
+	StringValue string `json:"stringValue,required"`
+	paramObj
+}
+
+func (r OTelStringValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelStringValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelStringValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property BoolValue is required.
+type OTelBoolValueParam struct {
+	BoolValue bool `json:"boolValue,required"`
+	paramObj
+}
+
+func (r OTelBoolValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelBoolValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelBoolValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property IntValue is required.
+type OTelIntValueParam struct {
+	IntValue int64 `json:"intValue,required"`
+	paramObj
+}
+
+func (r OTelIntValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelIntValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelIntValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property DoubleValue is required.
+type OTelDoubleValueParam struct {
+	DoubleValue float64 `json:"doubleValue,required"`
+	paramObj
+}
+
+func (r OTelDoubleValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelDoubleValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelDoubleValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property BytesValue is required.
+type OTelBytesValueParam struct {
+	// Sent base64 encoded.
+	BytesValue []byte `json:"bytesValue,omitzero,required"`
+	paramObj
+}
+
+func (r OTelBytesValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelBytesValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelBytesValueParam) UnmarshalJSON(data []byte) error {
+	// apijson does not decode base64.
+	var v struct {
+		BytesValue []byte `json:"bytesValue"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	r.BytesValue = v.BytesValue
+	return nil
+}
+
+// The property ArrayValue is required.
+type OTelArrayValueVariantParam struct {
+	ArrayValue OTelArrayValueParam `json:"arrayValue,omitzero,required"`
+	paramObj
+}
+
+func (r OTelArrayValueVariantParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelArrayValueVariantParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelArrayValueVariantParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property KvlistValue is required.
+type OTelKvlistValueParam struct {
+	KvlistValue OTelKeyValueListParam `json:"kvlistValue,omitzero,required"`
+	paramObj
+}
+
+func (r OTelKvlistValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelKvlistValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelKvlistValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property Values is required.
+type OTelArrayValueParam struct {
+	Values []OTelAnyValueParam `json:"values,omitzero,required"`
+	paramObj
+}
+
+func (r OTelArrayValueParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelArrayValueParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelArrayValueParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The property Values is required.
+type OTelKeyValueListParam struct {
+	Values []OTelKeyValueParam `json:"values,omitzero,required"`
+	paramObj
+}
+
+func (r OTelKeyValueListParam) MarshalJSON() (data []byte, err error) {
+	type shadow OTelKeyValueListParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *OTelKeyValueListParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
@@ -0,0 +1,130 @@
+// Package redact masks credentials and sensitive content before requests and
+// responses are logged or dumped. Its [Mode] is used by option.WithLogger and by
+// the redacted dumps of aidr.Error.
+package redact
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"unicode/utf8"
+)
+
+// Mode controls how a body is rendered.
+type Mode int8
+
+const (
+	// Omit leaves the body out entirely.
+	Omit Mode = iota
+	// Hash replaces the body with its length and SHA-256 digest, which allows
+	// correlating identical payloads without revealing them.
+	Hash
+	// Truncate keeps only the beginning of the body.
+	Truncate
+	// Full keeps the body unchanged.
+	Full
+)
+
+// Mask replaces the value of sensitive headers.
+const Mask = "[REDACTED]"
+
+// DefaultTruncateAt is the number of bytes kept by [Truncate] when no limit is
+// given.
+const DefaultTruncateAt = 256
+
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// Header returns a copy of h where the values of sensitive headers are masked.
+func Header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range sensitiveHeaders {
+		if _, ok := h[key]; ok {
+			h.Set(key, Mask)
+		}
+	}
+	return h
+}
+
+// Body renders b according to mode. limit is the number of bytes kept by
+// [Truncate], [DefaultTruncateAt] is used when it is not positive.
+func Body(b []byte, mode Mode, limit int) string {
+	switch mode {
+	case Hash:
+		sum := sha256.Sum256(b)
+		return fmt.Sprintf("[%d bytes sha256:%s]", len(b), hex.EncodeToString(sum[:]))
+	case Truncate:
+		if limit <= 0 {
+			limit = DefaultTruncateAt
+		}
+		if len(b) <= limit {
+			return string(b)
+		}
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(b[cut]) {
+			cut--
+		}
+		return fmt.Sprintf("%s...[%d bytes truncated]", b[:cut], len(b)-cut)
+	case Full:
+		return string(b)
+	}
+	return ""
+}
+
+// DumpRequest is like [httputil.DumpRequestOut], but masks sensitive headers
+// and renders the body according to mode. The body of req is read through
+// GetBody, so req can still be sent or retried.
+func DumpRequest(req *http.Request, mode Mode) []byte {
+	masked := req.Clone(context.Background())
+	masked.Header = Header(req.Header)
+	masked.Body = nil
+	masked.ContentLength = 0
+	out, _ := httputil.DumpRequestOut(masked, false)
+	if mode == Omit || req.GetBody == nil {
+		return out
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return out
+	}
+	defer body.Close()
+	b, _ := io.ReadAll(body)
+	return append(out, Body(b, mode, 0)...)
+}
+
+// DumpResponse is like [httputil.DumpResponse], but masks sensitive headers and
+// renders the body according to mode. Unless mode is [Omit], the body of res is
+// read and replaced so that it can still be consumed.
+func DumpResponse(res *http.Response, mode Mode) []byte {
+	masked := *res
+	masked.Header = Header(res.Header)
+	out, _ := httputil.DumpResponse(&masked, false)
+	if mode == Omit || res.Body == nil {
+		return out
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		// Put back what was read, so that the reader still sees the rest of the
+		// body and the read error.
+		res.Body = readCloser{io.MultiReader(bytes.NewReader(b), res.Body), res.Body}
+		return out
+	}
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	return append(out, Body(b, mode, 0)...)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
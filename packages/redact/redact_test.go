@@ -0,0 +1,51 @@
+package redact_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go/packages/redact"
+)
+
+func TestHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("Content-Type", "application/json")
+
+	masked := redact.Header(h)
+	if got := masked.Get("Authorization"); got != redact.Mask {
+		t.Errorf("expected masked authorization, received %q", got)
+	}
+	if got := masked.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected content type to be kept, received %q", got)
+	}
+	if got := h.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("expected original header to be untouched, received %q", got)
+	}
+}
+
+func TestBody(t *testing.T) {
+	body := []byte(`{"messages":[{"role":"user","content":"my ssn is 123-45-6789"}]}`)
+
+	if got := redact.Body(body, redact.Omit, 0); got != "" {
+		t.Errorf("expected omitted body, received %q", got)
+	}
+	if got := redact.Body(body, redact.Full, 0); got != string(body) {
+		t.Errorf("expected full body, received %q", got)
+	}
+	hashed := redact.Body(body, redact.Hash, 0)
+	if strings.Contains(hashed, "123-45-6789") || !strings.Contains(hashed, "sha256:") {
+		t.Errorf("expected hashed body, received %q", hashed)
+	}
+	if redact.Body(body, redact.Hash, 0) != hashed {
+		t.Errorf("expected hashing to be deterministic")
+	}
+	truncated := redact.Body(body, redact.Truncate, 10)
+	if truncated != `{"messages...[54 bytes truncated]` {
+		t.Errorf("unexpected truncated body %q", truncated)
+	}
+	if got := redact.Body([]byte("héllo"), redact.Truncate, 2); got != "h...[5 bytes truncated]" {
+		t.Errorf("expected truncation on a rune boundary, received %q", got)
+	}
+}
@@ -0,0 +1,836 @@
+package aidr
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// An AIDR policy, as described by the `aidr-policy-result` schema. The spec
+// doesn't publish the routes of the policy management API, so the SDK has no
+// service for it.
+type Policy struct {
+	// A Policy ID
+	ID string `json:"id,required"`
+	// Unique identifier for the policy
+	Key string `json:"key,required"`
+	// A friendly display name for the policy
+	Name string `json:"name,required"`
+	// The current revision of the policy
+	Revision float64 `json:"revision,required"`
+	// The schema version used for the policy definition
+	//
+	// Any of "v1.1".
+	SchemaVersion PolicySchemaVersion `json:"schema_version,required"`
+	// Configuration for access rules used in an AIDR policy.
+	AccessRules []AccessRuleSetting `json:"access_rules"`
+	// Connector-level Redact configuration. These settings allow you to define
+	// reusable redaction parameters, such as FPE tweak value.
+	ConnectorSettings ConnectorSettings `json:"connector_settings"`
+	// Timestamp when the record was created (RFC 3339 format)
+	CreatedAt time.Time `json:"created_at" format:"date-time"`
+	// A detailed description for the policy
+	Description string `json:"description"`
+	// Settings for Detectors, including which detectors to enable and how they
+	// behave
+	DetectorSettings []DetectorSetting `json:"detector_settings"`
+	// Timestamp when the record was last updated (RFC 3339 format)
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID                respjson.Field
+		Key               respjson.Field
+		Name              respjson.Field
+		Revision          respjson.Field
+		SchemaVersion     respjson.Field
+		AccessRules       respjson.Field
+		ConnectorSettings respjson.Field
+		CreatedAt         respjson.Field
+		Description       respjson.Field
+		DetectorSettings  respjson.Field
+		UpdatedAt         respjson.Field
+		ExtraFields       map[string]respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r Policy) RawJSON() string { return r.JSON.raw }
+
+func (r *Policy) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The schema version used for the policy definition
+type PolicySchemaVersion string
+
+const (
+	PolicySchemaVersionV1_1 PolicySchemaVersion = "v1.1"
+)
+
+// A page of policies, as described by the `aidr-policy-search-result` schema.
+type PolicySearchResult struct {
+	// Pagination limit
+	Count int64 `json:"count"`
+	// Pagination last count
+	Last     string   `json:"last"`
+	Policies []Policy `json:"policies"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count       respjson.Field
+		Last        respjson.Field
+		Policies    respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r PolicySearchResult) RawJSON() string { return r.JSON.raw }
+
+func (r *PolicySearchResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The default policies, as described by the `aidr-policy-defaults` schema.
+type PolicyDefaults struct {
+	// The default policies, keyed by policy key.
+	DefaultPolicies map[string]RecipeConfig `json:"default_policies,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		DefaultPolicies respjson.Field
+		ExtraFields     map[string]respjson.Field
+		raw             string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r PolicyDefaults) RawJSON() string { return r.JSON.raw }
+
+func (r *PolicyDefaults) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Defines an AI Guard recipe - a named configuration of detectors and redaction
+// settings used to analyze and protect data flows in AI-powered applications.
+//
+// Recipes specify which detectors are active, how they behave, and may include
+// reusable settings such as FPE tweaks.
+type RecipeConfig struct {
+	// Detailed description of the recipe's purpose or use case
+	Description string `json:"description,required"`
+	// Human-readable name of the recipe
+	Name string `json:"name,required"`
+	// Configuration for access rules used in an AI Guard recipe.
+	AccessRules []AccessRuleSetting `json:"access_rules"`
+	// Connector-level Redact configuration. These settings allow you to define
+	// reusable redaction parameters, such as FPE tweak value.
+	ConnectorSettings ConnectorSettings `json:"connector_settings"`
+	// Settings for AI Guard Detectors, including which detectors to enable and how
+	// they behave
+	Detectors []DetectorSetting `json:"detectors"`
+	// Optional version identifier for the recipe. Can be used to track changes.
+	Version string `json:"version"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Description       respjson.Field
+		Name              respjson.Field
+		AccessRules       respjson.Field
+		ConnectorSettings respjson.Field
+		Detectors         respjson.Field
+		Version           respjson.Field
+		ExtraFields       map[string]respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RecipeConfig) RawJSON() string { return r.JSON.raw }
+
+func (r *RecipeConfig) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Configuration for an individual detector. Each entry specifies the detector to
+// use, its enabled state, detector-specific settings, and the action to apply when
+// detections occur.
+type DetectorSetting struct {
+	// Identifier of the detector to apply, such as `prompt_injection`,
+	// `pii_entity`, or `malicious_entity`
+	DetectorName string `json:"detector_name,required"`
+	// Detector-specific settings
+	Settings DetectorSettingSettings `json:"settings,required"`
+	// Specifies whether the detector is enabled or disabled in this configuration
+	//
+	// Any of "disabled", "enabled".
+	State DetectorSettingState `json:"state,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		DetectorName respjson.Field
+		Settings     respjson.Field
+		State        respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r DetectorSetting) RawJSON() string { return r.JSON.raw }
+
+func (r *DetectorSetting) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this DetectorSetting to a DetectorSettingParam.
+//
+// Warning: the fields of the param type will not be present. ToParam should only
+// be used at the last possible moment before sending a request. Test for this
+// with DetectorSettingParam.Overrides()
+func (r DetectorSetting) ToParam() DetectorSettingParam {
+	return param.Override[DetectorSettingParam](json.RawMessage(r.RawJSON()))
+}
+
+// Specifies whether the detector is enabled or disabled in this configuration
+type DetectorSettingState string
+
+const (
+	DetectorSettingStateDisabled DetectorSettingState = "disabled"
+	DetectorSettingStateEnabled  DetectorSettingState = "enabled"
+)
+
+// Detector-specific settings
+type DetectorSettingSettings struct {
+	// List of detection and redaction rules applied by this detector
+	Rules []DetectorSettingSettingsRule `json:"rules"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Rules       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r DetectorSettingSettings) RawJSON() string { return r.JSON.raw }
+
+func (r *DetectorSettingSettings) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Defines redaction behavior and flags for a specific rule used by the detector
+type DetectorSettingSettingsRule struct {
+	// Identifier of the redaction rule to apply. This should match a rule defined in
+	// the Redact service.
+	RedactRuleID string `json:"redact_rule_id,required"`
+	// Configuration for the redaction method applied to detected values.
+	Redaction RuleRedactionConfig `json:"redaction,required"`
+	// If `true`, indicates that further processing should be stopped when this rule
+	// is triggered
+	Block bool `json:"block"`
+	// If `true`, disables this specific rule even if the detector is enabled
+	Disabled bool `json:"disabled"`
+	// If `true`, performs a reputation check using the configured intel provider.
+	// Applies to the Malicious Entity detector when using IP, URL, or Domain Intel
+	// services.
+	ReputationCheck bool `json:"reputation_check"`
+	// If `true`, applies redaction or transformation when the detected value is
+	// determined to be malicious by intel analysis
+	TransformIfMalicious bool `json:"transform_if_malicious"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		RedactRuleID         respjson.Field
+		Redaction            respjson.Field
+		Block                respjson.Field
+		Disabled             respjson.Field
+		ReputationCheck      respjson.Field
+		TransformIfMalicious respjson.Field
+		ExtraFields          map[string]respjson.Field
+		raw                  string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r DetectorSettingSettingsRule) RawJSON() string { return r.JSON.raw }
+
+func (r *DetectorSettingSettingsRule) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Configuration for the redaction method applied to detected values.
+//
+// Each rule supports one redaction type, such as masking, replacement, hashing,
+// Format-Preserving Encryption (FPE), or detection-only mode. Additional
+// parameters may be required depending on the selected redaction type.
+type RuleRedactionConfig struct {
+	// Redaction method to apply for this rule
+	//
+	// Any of "mask", "partial_masking", "replacement", "hash", "detect_only", "fpe".
+	RedactionType RuleRedactionConfigRedactionType `json:"redaction_type,required"`
+	// Alphabet used for Format-Preserving Encryption (FPE). Determines the character
+	// set for encryption.
+	//
+	// Any of "numeric", "alphalower", "alphaupper", "alpha", "alphanumericlower",
+	// "alphanumericupper", "alphanumeric".
+	FpeAlphabet RuleRedactionConfigFpeAlphabet `json:"fpe_alphabet,nullable"`
+	// Hash configuration when `redaction_type` is `hash`
+	Hash RuleRedactionConfigHash `json:"hash,nullable"`
+	// Parameters to control how text is masked when `redaction_type` is
+	// `partial_masking`
+	PartialMasking RuleRedactionConfigPartialMasking `json:"partial_masking"`
+	// Replacement string to use when `redaction_type` is `replacement`
+	RedactionValue string `json:"redaction_value"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		RedactionType  respjson.Field
+		FpeAlphabet    respjson.Field
+		Hash           respjson.Field
+		PartialMasking respjson.Field
+		RedactionValue respjson.Field
+		ExtraFields    map[string]respjson.Field
+		raw            string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RuleRedactionConfig) RawJSON() string { return r.JSON.raw }
+
+func (r *RuleRedactionConfig) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Redaction method to apply for this rule
+type RuleRedactionConfigRedactionType string
+
+const (
+	RuleRedactionConfigRedactionTypeMask           RuleRedactionConfigRedactionType = "mask"
+	RuleRedactionConfigRedactionTypePartialMasking RuleRedactionConfigRedactionType = "partial_masking"
+	RuleRedactionConfigRedactionTypeReplacement    RuleRedactionConfigRedactionType = "replacement"
+	RuleRedactionConfigRedactionTypeHash           RuleRedactionConfigRedactionType = "hash"
+	RuleRedactionConfigRedactionTypeDetectOnly     RuleRedactionConfigRedactionType = "detect_only"
+	RuleRedactionConfigRedactionTypeFpe            RuleRedactionConfigRedactionType = "fpe"
+)
+
+// Alphabet used for Format-Preserving Encryption (FPE). Determines the character
+// set for encryption.
+type RuleRedactionConfigFpeAlphabet string
+
+const (
+	RuleRedactionConfigFpeAlphabetNumeric           RuleRedactionConfigFpeAlphabet = "numeric"
+	RuleRedactionConfigFpeAlphabetAlphalower        RuleRedactionConfigFpeAlphabet = "alphalower"
+	RuleRedactionConfigFpeAlphabetAlphaupper        RuleRedactionConfigFpeAlphabet = "alphaupper"
+	RuleRedactionConfigFpeAlphabetAlpha             RuleRedactionConfigFpeAlphabet = "alpha"
+	RuleRedactionConfigFpeAlphabetAlphanumericlower RuleRedactionConfigFpeAlphabet = "alphanumericlower"
+	RuleRedactionConfigFpeAlphabetAlphanumericupper RuleRedactionConfigFpeAlphabet = "alphanumericupper"
+	RuleRedactionConfigFpeAlphabetAlphanumeric      RuleRedactionConfigFpeAlphabet = "alphanumeric"
+)
+
+// Hash configuration when `redaction_type` is `hash`
+type RuleRedactionConfigHash struct {
+	// Hashing algorithm to use for redaction
+	//
+	// Any of "md5", "sha256".
+	HashType RuleRedactionConfigHashHashType `json:"hash_type,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		HashType    respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RuleRedactionConfigHash) RawJSON() string { return r.JSON.raw }
+
+func (r *RuleRedactionConfigHash) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Hashing algorithm to use for redaction
+type RuleRedactionConfigHashHashType string
+
+const (
+	RuleRedactionConfigHashHashTypeMd5    RuleRedactionConfigHashHashType = "md5"
+	RuleRedactionConfigHashHashTypeSha256 RuleRedactionConfigHashHashType = "sha256"
+)
+
+// Parameters to control how text is masked when `redaction_type` is
+// `partial_masking`
+type RuleRedactionConfigPartialMasking struct {
+	// List of characters that should not be masked (for example, hyphens or periods)
+	CharsToIgnore []string `json:"chars_to_ignore"`
+	// Number of leading characters to mask when `masking_type` is `mask`
+	MaskedFromLeft int64 `json:"masked_from_left"`
+	// Number of trailing characters to mask when `masking_type` is `mask`
+	MaskedFromRight int64 `json:"masked_from_right"`
+	// Character to use when masking text
+	MaskingChar string `json:"masking_char"`
+	// Defines the masking strategy. Use `unmask` to specify how many characters to
+	// keep visible. Use `mask` to specify how many to hide.
+	//
+	// Any of "unmask", "mask".
+	MaskingType RuleRedactionConfigPartialMaskingMaskingType `json:"masking_type"`
+	// Number of leading characters to leave unmasked when `masking_type` is `unmask`
+	UnmaskedFromLeft int64 `json:"unmasked_from_left"`
+	// Number of trailing characters to leave unmasked when `masking_type` is
+	// `unmask`
+	UnmaskedFromRight int64 `json:"unmasked_from_right"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CharsToIgnore     respjson.Field
+		MaskedFromLeft    respjson.Field
+		MaskedFromRight   respjson.Field
+		MaskingChar       respjson.Field
+		MaskingType       respjson.Field
+		UnmaskedFromLeft  respjson.Field
+		UnmaskedFromRight respjson.Field
+		ExtraFields       map[string]respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r RuleRedactionConfigPartialMasking) RawJSON() string { return r.JSON.raw }
+
+func (r *RuleRedactionConfigPartialMasking) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Defines the masking strategy. Use `unmask` to specify how many characters to
+// keep visible. Use `mask` to specify how many to hide.
+type RuleRedactionConfigPartialMaskingMaskingType string
+
+const (
+	RuleRedactionConfigPartialMaskingMaskingTypeUnmask RuleRedactionConfigPartialMaskingMaskingType = "unmask"
+	RuleRedactionConfigPartialMaskingMaskingTypeMask   RuleRedactionConfigPartialMaskingMaskingType = "mask"
+)
+
+// Configuration for an individual access rule. Each rule defines its matching
+// logic and the action to apply when the logic evaluates to true.
+type AccessRuleSetting struct {
+	// JSON Logic condition that determines whether this rule matches.
+	Logic map[string]any `json:"logic,required"`
+	// Display label for the rule shown in user interfaces.
+	Name string `json:"name,required"`
+	// Unique identifier for this rule. Should be user-readable and consistent across
+	// recipe updates.
+	RuleKey string `json:"rule_key,required"`
+	// Action to apply if the rule matches. Use 'block' to stop further processing or
+	// 'report' to simply log the match.
+	//
+	// Any of "block", "report".
+	State AccessRuleSettingState `json:"state,required"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Logic       respjson.Field
+		Name        respjson.Field
+		RuleKey     respjson.Field
+		State       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r AccessRuleSetting) RawJSON() string { return r.JSON.raw }
+
+func (r *AccessRuleSetting) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this AccessRuleSetting to a AccessRuleSettingParam.
+//
+// Warning: the fields of the param type will not be present. ToParam should only
+// be used at the last possible moment before sending a request. Test for this
+// with AccessRuleSettingParam.Overrides()
+func (r AccessRuleSetting) ToParam() AccessRuleSettingParam {
+	return param.Override[AccessRuleSettingParam](json.RawMessage(r.RawJSON()))
+}
+
+// Action to apply if the rule matches. Use 'block' to stop further processing or
+// 'report' to simply log the match.
+type AccessRuleSettingState string
+
+const (
+	AccessRuleSettingStateBlock  AccessRuleSettingState = "block"
+	AccessRuleSettingStateReport AccessRuleSettingState = "report"
+)
+
+// Connector-level Redact configuration. These settings allow you to define
+// reusable redaction parameters, such as FPE tweak value.
+type ConnectorSettings struct {
+	// Settings for Redact integration at the policy level
+	Redact ConnectorSettingsRedact `json:"redact"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Redact      respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConnectorSettings) RawJSON() string { return r.JSON.raw }
+
+func (r *ConnectorSettings) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// ToParam converts this ConnectorSettings to a ConnectorSettingsParam.
+//
+// Warning: the fields of the param type will not be present. ToParam should only
+// be used at the last possible moment before sending a request. Test for this
+// with ConnectorSettingsParam.Overrides()
+func (r ConnectorSettings) ToParam() ConnectorSettingsParam {
+	return param.Override[ConnectorSettingsParam](json.RawMessage(r.RawJSON()))
+}
+
+// Settings for Redact integration at the policy level
+type ConnectorSettingsRedact struct {
+	// ID of a Vault secret containing the tweak value used for Format-Preserving
+	// Encryption (FPE). Enables deterministic encryption, ensuring that identical
+	// inputs produce consistent encrypted outputs.
+	FpeTweakVaultSecretID string `json:"fpe_tweak_vault_secret_id"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		FpeTweakVaultSecretID respjson.Field
+		ExtraFields           map[string]respjson.Field
+		raw                   string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ConnectorSettingsRedact) RawJSON() string { return r.JSON.raw }
+
+func (r *ConnectorSettingsRedact) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A policy definition, as described by the `aidr-policy` schema.
+//
+// The properties Key, Name, SchemaVersion are required.
+type PolicyParams struct {
+	// Unique identifier for the policy
+	Key string `json:"key,required"`
+	// A friendly display name for the policy
+	Name string `json:"name,required"`
+	// The schema version used for the policy definition
+	//
+	// Any of "v1.1".
+	SchemaVersion PolicySchemaVersion `json:"schema_version,omitzero,required"`
+	// A detailed description for the policy
+	Description param.Opt[string] `json:"description,omitzero"`
+	// Configuration for access rules used in an AIDR policy.
+	AccessRules []AccessRuleSettingParam `json:"access_rules,omitzero"`
+	// Connector-level Redact configuration. These settings allow you to define
+	// reusable redaction parameters, such as FPE tweak value.
+	ConnectorSettings ConnectorSettingsParam `json:"connector_settings,omitzero"`
+	// Settings for Detectors, including which detectors to enable and how they
+	// behave
+	DetectorSettings []DetectorSettingParam `json:"detector_settings,omitzero"`
+	paramObj
+}
+
+func (r PolicyParams) MarshalJSON() (data []byte, err error) {
+	type shadow PolicyParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *PolicyParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Filters for listing policies, as described by the `aidr-policy-search`
+// schema.
+type PolicySearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]         `json:"size,omitzero"`
+	Filter PolicySearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order PolicySearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "key", "name", "created_at", "updated_at".
+	OrderBy PolicySearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r PolicySearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow PolicySearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *PolicySearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type PolicySearchParamsFilter struct {
+	// Only records where key is equal to the value
+	Key param.Opt[string] `json:"key,omitzero"`
+	// Only records where status equals this value.
+	Status param.Opt[string] `json:"status,omitzero"`
+	// Only records where key includes each substring.
+	KeyContains []string `json:"key__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	NameIn []string `json:"name__in,omitzero"`
+	paramObj
+}
+
+func (r PolicySearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow PolicySearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *PolicySearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type PolicySearchParamsOrder string
+
+const (
+	PolicySearchParamsOrderAsc  PolicySearchParamsOrder = "asc"
+	PolicySearchParamsOrderDesc PolicySearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type PolicySearchParamsOrderBy string
+
+const (
+	PolicySearchParamsOrderByKey       PolicySearchParamsOrderBy = "key"
+	PolicySearchParamsOrderByName      PolicySearchParamsOrderBy = "name"
+	PolicySearchParamsOrderByCreatedAt PolicySearchParamsOrderBy = "created_at"
+	PolicySearchParamsOrderByUpdatedAt PolicySearchParamsOrderBy = "updated_at"
+)
+
+// Configuration for an individual detector. Each entry specifies the detector to
+// use, its enabled state, detector-specific settings, and the action to apply when
+// detections occur.
+//
+// The properties DetectorName, Settings, State are required.
+type DetectorSettingParam struct {
+	// Identifier of the detector to apply, such as `prompt_injection`,
+	// `pii_entity`, or `malicious_entity`
+	DetectorName string `json:"detector_name,required"`
+	// Detector-specific settings
+	Settings DetectorSettingSettingsParam `json:"settings,omitzero,required"`
+	// Specifies whether the detector is enabled or disabled in this configuration
+	//
+	// Any of "disabled", "enabled".
+	State DetectorSettingState `json:"state,omitzero,required"`
+	paramObj
+}
+
+func (r DetectorSettingParam) MarshalJSON() (data []byte, err error) {
+	type shadow DetectorSettingParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *DetectorSettingParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Detector-specific settings
+type DetectorSettingSettingsParam struct {
+	// List of detection and redaction rules applied by this detector
+	Rules []DetectorSettingSettingsRuleParam `json:"rules,omitzero"`
+	paramObj
+}
+
+func (r DetectorSettingSettingsParam) MarshalJSON() (data []byte, err error) {
+	type shadow DetectorSettingSettingsParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *DetectorSettingSettingsParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Defines redaction behavior and flags for a specific rule used by the detector
+//
+// The properties RedactRuleID, Redaction are required.
+type DetectorSettingSettingsRuleParam struct {
+	// Identifier of the redaction rule to apply. This should match a rule defined in
+	// the Redact service.
+	RedactRuleID string `json:"redact_rule_id,required"`
+	// Configuration for the redaction method applied to detected values.
+	Redaction RuleRedactionConfigParam `json:"redaction,omitzero,required"`
+	// If `true`, indicates that further processing should be stopped when this rule
+	// is triggered
+	Block param.Opt[bool] `json:"block,omitzero"`
+	// If `true`, disables this specific rule even if the detector is enabled
+	Disabled param.Opt[bool] `json:"disabled,omitzero"`
+	// If `true`, performs a reputation check using the configured intel provider.
+	// Applies to the Malicious Entity detector when using IP, URL, or Domain Intel
+	// services.
+	ReputationCheck param.Opt[bool] `json:"reputation_check,omitzero"`
+	// If `true`, applies redaction or transformation when the detected value is
+	// determined to be malicious by intel analysis
+	TransformIfMalicious param.Opt[bool] `json:"transform_if_malicious,omitzero"`
+	paramObj
+}
+
+func (r DetectorSettingSettingsRuleParam) MarshalJSON() (data []byte, err error) {
+	type shadow DetectorSettingSettingsRuleParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *DetectorSettingSettingsRuleParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Configuration for the redaction method applied to detected values.
+//
+// The property RedactionType is required.
+type RuleRedactionConfigParam struct {
+	// Redaction method to apply for this rule
+	//
+	// Any of "mask", "partial_masking", "replacement", "hash", "detect_only", "fpe".
+	RedactionType RuleRedactionConfigRedactionType `json:"redaction_type,omitzero,required"`
+	// Replacement string to use when `redaction_type` is `replacement`
+	RedactionValue param.Opt[string] `json:"redaction_value,omitzero"`
+	// Alphabet used for Format-Preserving Encryption (FPE), required when
+	// `redaction_type` is `fpe`.
+	//
+	// Any of "numeric", "alphalower", "alphaupper", "alpha", "alphanumericlower",
+	// "alphanumericupper", "alphanumeric".
+	FpeAlphabet RuleRedactionConfigFpeAlphabet `json:"fpe_alphabet,omitzero"`
+	// Hash configuration when `redaction_type` is `hash`
+	Hash RuleRedactionConfigHashParam `json:"hash,omitzero"`
+	// Parameters to control how text is masked when `redaction_type` is
+	// `partial_masking`
+	PartialMasking RuleRedactionConfigPartialMaskingParam `json:"partial_masking,omitzero"`
+	paramObj
+}
+
+func (r RuleRedactionConfigParam) MarshalJSON() (data []byte, err error) {
+	type shadow RuleRedactionConfigParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *RuleRedactionConfigParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Hash configuration when `redaction_type` is `hash`
+//
+// The property HashType is required.
+type RuleRedactionConfigHashParam struct {
+	// Hashing algorithm to use for redaction
+	//
+	// Any of "md5", "sha256".
+	HashType RuleRedactionConfigHashHashType `json:"hash_type,omitzero,required"`
+	paramObj
+}
+
+func (r RuleRedactionConfigHashParam) MarshalJSON() (data []byte, err error) {
+	type shadow RuleRedactionConfigHashParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *RuleRedactionConfigHashParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Parameters to control how text is masked when `redaction_type` is
+// `partial_masking`
+type RuleRedactionConfigPartialMaskingParam struct {
+	// Number of leading characters to mask when `masking_type` is `mask`
+	MaskedFromLeft param.Opt[int64] `json:"masked_from_left,omitzero"`
+	// Number of trailing characters to mask when `masking_type` is `mask`
+	MaskedFromRight param.Opt[int64] `json:"masked_from_right,omitzero"`
+	// Character to use when masking text
+	MaskingChar param.Opt[string] `json:"masking_char,omitzero"`
+	// Number of leading characters to leave unmasked when `masking_type` is `unmask`
+	UnmaskedFromLeft param.Opt[int64] `json:"unmasked_from_left,omitzero"`
+	// Number of trailing characters to leave unmasked when `masking_type` is
+	// `unmask`
+	UnmaskedFromRight param.Opt[int64] `json:"unmasked_from_right,omitzero"`
+	// List of characters that should not be masked (for example, hyphens or periods)
+	CharsToIgnore []string `json:"chars_to_ignore,omitzero"`
+	// Defines the masking strategy. Use `unmask` to specify how many characters to
+	// keep visible. Use `mask` to specify how many to hide.
+	//
+	// Any of "unmask", "mask".
+	MaskingType RuleRedactionConfigPartialMaskingMaskingType `json:"masking_type,omitzero"`
+	paramObj
+}
+
+func (r RuleRedactionConfigPartialMaskingParam) MarshalJSON() (data []byte, err error) {
+	type shadow RuleRedactionConfigPartialMaskingParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *RuleRedactionConfigPartialMaskingParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Configuration for an individual access rule. Each rule defines its matching
+// logic and the action to apply when the logic evaluates to true.
+//
+// The properties Logic, Name, RuleKey, State are required.
+type AccessRuleSettingParam struct {
+	// JSON Logic condition that determines whether this rule matches.
+	Logic map[string]any `json:"logic,omitzero,required"`
+	// Display label for the rule shown in user interfaces.
+	Name string `json:"name,required"`
+	// Unique identifier for this rule. Should be user-readable and consistent across
+	// recipe updates.
+	RuleKey string `json:"rule_key,required"`
+	// Action to apply if the rule matches. Use 'block' to stop further processing or
+	// 'report' to simply log the match.
+	//
+	// Any of "block", "report".
+	State AccessRuleSettingState `json:"state,omitzero,required"`
+	paramObj
+}
+
+func (r AccessRuleSettingParam) MarshalJSON() (data []byte, err error) {
+	type shadow AccessRuleSettingParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *AccessRuleSettingParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Connector-level Redact configuration. These settings allow you to define
+// reusable redaction parameters, such as FPE tweak value.
+type ConnectorSettingsParam struct {
+	// Settings for Redact integration at the policy level
+	Redact ConnectorSettingsRedactParam `json:"redact,omitzero"`
+	paramObj
+}
+
+func (r ConnectorSettingsParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConnectorSettingsParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ConnectorSettingsParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Settings for Redact integration at the policy level
+type ConnectorSettingsRedactParam struct {
+	// ID of a Vault secret containing the tweak value used for Format-Preserving
+	// Encryption (FPE). Enables deterministic encryption, ensuring that identical
+	// inputs produce consistent encrypted outputs.
+	FpeTweakVaultSecretID param.Opt[string] `json:"fpe_tweak_vault_secret_id,omitzero"`
+	paramObj
+}
+
+func (r ConnectorSettingsRedactParam) MarshalJSON() (data []byte, err error) {
+	type shadow ConnectorSettingsRedactParam
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ConnectorSettingsRedactParam) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
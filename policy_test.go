@@ -0,0 +1,134 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+const policyJSON = `{
+	"id": "pap_xpkhwpnz2cmegsws737xbsqnmnuwtbm5",
+	"key": "k_chat",
+	"name": "Chat",
+	"schema_version": "v1.1",
+	"revision": 3,
+	"detector_settings": [{
+		"detector_name": "pii_entity",
+		"state": "enabled",
+		"settings": {"rules": [{
+			"redact_rule_id": "US_SSN",
+			"redaction": {"redaction_type": "hash", "hash": {"hash_type": "sha256"}},
+			"block": true
+		}]}
+	}],
+	"access_rules": [{
+		"rule_key": "block_outside_us",
+		"name": "Block Outside US",
+		"state": "block",
+		"logic": {"!=": [{"var": "user.source_location"}, "US"]}
+	}],
+	"connector_settings": {"redact": {"fpe_tweak_vault_secret_id": "pvi_123"}},
+	"created_at": "2025-01-02T03:04:05Z"
+}`
+
+// marshalObject marshals v and decodes the result back as a JSON object.
+func marshalObject(t *testing.T, v any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(b, &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestPolicyParams(t *testing.T) {
+	body := marshalObject(t, aidr.PolicyParams{
+		Key:           "k_chat",
+		Name:          "Chat",
+		SchemaVersion: aidr.PolicySchemaVersionV1_1,
+		DetectorSettings: []aidr.DetectorSettingParam{{
+			DetectorName: "pii_entity",
+			State:        aidr.DetectorSettingStateEnabled,
+			Settings: aidr.DetectorSettingSettingsParam{
+				Rules: []aidr.DetectorSettingSettingsRuleParam{{
+					RedactRuleID: "US_SSN",
+					Redaction: aidr.RuleRedactionConfigParam{
+						RedactionType: aidr.RuleRedactionConfigRedactionTypeHash,
+						Hash:          aidr.RuleRedactionConfigHashParam{HashType: aidr.RuleRedactionConfigHashHashTypeSha256},
+					},
+					Block: aidr.Bool(true),
+				}},
+			},
+		}},
+	})
+
+	if body["key"] != "k_chat" || body["schema_version"] != "v1.1" {
+		t.Errorf("unexpected body %v", body)
+	}
+	rule := body["detector_settings"].([]any)[0].(map[string]any)["settings"].(map[string]any)["rules"].([]any)[0].(map[string]any)
+	if rule["redaction"].(map[string]any)["hash"].(map[string]any)["hash_type"] != "sha256" || rule["block"] != true {
+		t.Errorf("unexpected rule %v", rule)
+	}
+	if _, ok := body["description"]; ok {
+		t.Errorf("unset fields should be omitted, got %v", body)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	var policy aidr.Policy
+	if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+		t.Fatal(err)
+	}
+
+	if policy.ID != "pap_xpkhwpnz2cmegsws737xbsqnmnuwtbm5" || policy.Revision != 3 || policy.SchemaVersion != aidr.PolicySchemaVersionV1_1 {
+		t.Errorf("unexpected policy %+v", policy)
+	}
+	if !policy.CreatedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) || !policy.UpdatedAt.IsZero() {
+		t.Errorf("unexpected timestamps %s, %s", policy.CreatedAt, policy.UpdatedAt)
+	}
+	if r := policy.DetectorSettings[0].Settings.Rules[0]; r.Redaction.Hash.HashType != aidr.RuleRedactionConfigHashHashTypeSha256 || !r.Block {
+		t.Errorf("unexpected rule %+v", r)
+	}
+	if a := policy.AccessRules[0]; a.State != aidr.AccessRuleSettingStateBlock || a.Logic["!="] == nil {
+		t.Errorf("unexpected access rule %+v", a)
+	}
+	if policy.ConnectorSettings.Redact.FpeTweakVaultSecretID != "pvi_123" {
+		t.Errorf("unexpected connector settings %+v", policy.ConnectorSettings)
+	}
+
+	// A retrieved policy can be sent back with changes.
+	body := marshalObject(t, aidr.PolicyParams{
+		Key:           policy.Key,
+		Name:          "Renamed",
+		SchemaVersion: policy.SchemaVersion,
+		AccessRules:   []aidr.AccessRuleSettingParam{policy.AccessRules[0].ToParam()},
+	})
+	if body["name"] != "Renamed" || body["access_rules"].([]any)[0].(map[string]any)["rule_key"] != "block_outside_us" {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestPolicySearch(t *testing.T) {
+	body := marshalObject(t, aidr.PolicySearchParams{
+		Filter:  aidr.PolicySearchParamsFilter{KeyContains: []string{"chat"}},
+		OrderBy: aidr.PolicySearchParamsOrderByUpdatedAt,
+		Size:    aidr.Int(10),
+	})
+	if body["filter"].(map[string]any)["key__contains"].([]any)[0] != "chat" || body["order_by"] != "updated_at" || body["size"] != float64(10) {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var res aidr.PolicySearchResult
+	if err := json.Unmarshal([]byte(`{"count": 1, "last": "cursor", "policies": [`+policyJSON+`]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != 1 || res.Last != "cursor" || res.Policies[0].Key != "k_chat" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
@@ -0,0 +1,180 @@
+package aidr
+
+import (
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A policy collection, as described by the `aidr-policycollection-result`
+// schema. The spec publishes no routes for policy collections, nor a schema for
+// creating or updating them, so only the search params and results are
+// modeled.
+type PolicyCollection struct {
+	// Unique identifier for the policy collection
+	Key string `json:"key"`
+	// Name of the policy collection
+	Name string `json:"name"`
+	// Settings for the policy collection
+	Settings map[string]any `json:"settings"`
+	// Type of the policy collection
+	//
+	// Any of "logging", "gateway", "browser", "application", "agentic".
+	Type      PolicyCollectionType `json:"type"`
+	CreatedAt time.Time            `json:"created_at" format:"date-time"`
+	UpdatedAt time.Time            `json:"updated_at" format:"date-time"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Key         respjson.Field
+		Name        respjson.Field
+		Settings    respjson.Field
+		Type        respjson.Field
+		CreatedAt   respjson.Field
+		UpdatedAt   respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r PolicyCollection) RawJSON() string { return r.JSON.raw }
+
+func (r *PolicyCollection) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Type of the policy collection
+type PolicyCollectionType string
+
+const (
+	PolicyCollectionTypeLogging     PolicyCollectionType = "logging"
+	PolicyCollectionTypeGateway     PolicyCollectionType = "gateway"
+	PolicyCollectionTypeBrowser     PolicyCollectionType = "browser"
+	PolicyCollectionTypeApplication PolicyCollectionType = "application"
+	PolicyCollectionTypeAgentic     PolicyCollectionType = "agentic"
+)
+
+// A page of policy collections, as described by the
+// `aidr-policycollection-search-result` schema.
+type PolicyCollectionSearchResult struct {
+	Collections []PolicyCollection `json:"collections"`
+	// Total number of policy collections
+	Count int64 `json:"count"`
+	// Pagination cursor
+	Last string `json:"last"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Collections respjson.Field
+		Count       respjson.Field
+		Last        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r PolicyCollectionSearchResult) RawJSON() string { return r.JSON.raw }
+
+func (r *PolicyCollectionSearchResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Filters for listing policy collections, as described by the
+// `aidr-policycollection-search` schema.
+type PolicyCollectionSearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]                   `json:"size,omitzero"`
+	Filter PolicyCollectionSearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order PolicyCollectionSearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "key", "name", "type", "created_at", "updated_at".
+	OrderBy PolicyCollectionSearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r PolicyCollectionSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow PolicyCollectionSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *PolicyCollectionSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type PolicyCollectionSearchParamsFilter struct {
+	// Only records where created_at equals this value.
+	CreatedAt param.Opt[time.Time] `json:"created_at,omitzero" format:"date-time"`
+	// Only records where created_at is greater than this value.
+	CreatedAtGt param.Opt[time.Time] `json:"created_at__gt,omitzero" format:"date-time"`
+	// Only records where created_at is greater than or equal to this value.
+	CreatedAtGte param.Opt[time.Time] `json:"created_at__gte,omitzero" format:"date-time"`
+	// Only records where created_at is less than this value.
+	CreatedAtLt param.Opt[time.Time] `json:"created_at__lt,omitzero" format:"date-time"`
+	// Only records where created_at is less than or equal to this value.
+	CreatedAtLte param.Opt[time.Time] `json:"created_at__lte,omitzero" format:"date-time"`
+	// Only records where key is equal to the value
+	Key param.Opt[string] `json:"key,omitzero"`
+	// Only records where name is equal to the value
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Only records where updated_at equals this value.
+	UpdatedAt param.Opt[time.Time] `json:"updated_at,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than this value.
+	UpdatedAtGt param.Opt[time.Time] `json:"updated_at__gt,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than or equal to this value.
+	UpdatedAtGte param.Opt[time.Time] `json:"updated_at__gte,omitzero" format:"date-time"`
+	// Only records where updated_at is less than this value.
+	UpdatedAtLt param.Opt[time.Time] `json:"updated_at__lt,omitzero" format:"date-time"`
+	// Only records where updated_at is less than or equal to this value.
+	UpdatedAtLte param.Opt[time.Time] `json:"updated_at__lte,omitzero" format:"date-time"`
+	// Only records where key includes each substring.
+	KeyContains []string `json:"key__contains,omitzero"`
+	// Only records where key equals one of the provided substrings.
+	KeyIn []string `json:"key__in,omitzero"`
+	// Only records where name includes each substring.
+	NameContains []string `json:"name__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	NameIn []string `json:"name__in,omitzero"`
+	// Only records where type is equal to the value
+	//
+	// Any of "logging", "gateway", "browser", "application", "agentic".
+	Type PolicyCollectionType `json:"type,omitzero"`
+	// Only records where type equals one of the provided values.
+	TypeIn []PolicyCollectionType `json:"type__in,omitzero"`
+	paramObj
+}
+
+func (r PolicyCollectionSearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow PolicyCollectionSearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *PolicyCollectionSearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type PolicyCollectionSearchParamsOrder string
+
+const (
+	PolicyCollectionSearchParamsOrderAsc  PolicyCollectionSearchParamsOrder = "asc"
+	PolicyCollectionSearchParamsOrderDesc PolicyCollectionSearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type PolicyCollectionSearchParamsOrderBy string
+
+const (
+	PolicyCollectionSearchParamsOrderByKey       PolicyCollectionSearchParamsOrderBy = "key"
+	PolicyCollectionSearchParamsOrderByName      PolicyCollectionSearchParamsOrderBy = "name"
+	PolicyCollectionSearchParamsOrderByType      PolicyCollectionSearchParamsOrderBy = "type"
+	PolicyCollectionSearchParamsOrderByCreatedAt PolicyCollectionSearchParamsOrderBy = "created_at"
+	PolicyCollectionSearchParamsOrderByUpdatedAt PolicyCollectionSearchParamsOrderBy = "updated_at"
+)
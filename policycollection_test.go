@@ -0,0 +1,59 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestPolicyCollectionSearchFilter(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	body := marshalObject(t, aidr.PolicyCollectionSearchParams{
+		Filter: aidr.PolicyCollectionSearchParamsFilter{
+			CreatedAtGte: aidr.Time(since),
+			UpdatedAtLt:  aidr.Time(since.AddDate(0, 2, 0)),
+			TypeIn:       []aidr.PolicyCollectionType{aidr.PolicyCollectionTypeGateway, aidr.PolicyCollectionTypeAgentic},
+			NameContains: []string{"Gate"},
+		},
+		Order: aidr.PolicyCollectionSearchParamsOrderDesc,
+	})
+
+	filter := body["filter"].(map[string]any)
+	if filter["created_at__gte"] != "2025-01-01T00:00:00Z" || filter["updated_at__lt"] != "2025-03-01T00:00:00Z" {
+		t.Errorf("unexpected timestamps in filter %v", filter)
+	}
+	if len(filter) != 4 || filter["type__in"].([]any)[1] != "agentic" || filter["name__contains"].([]any)[0] != "Gate" {
+		t.Errorf("unexpected filter %v", filter)
+	}
+	if body["order"] != "desc" {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestPolicyCollectionSearchResult(t *testing.T) {
+	var res aidr.PolicyCollectionSearchResult
+	err := json.Unmarshal([]byte(`{"count": 1, "last": "cursor", "collections": [{
+		"key": "gateway",
+		"name": "Gateway",
+		"type": "gateway",
+		"settings": {"mode": "strict"},
+		"created_at": "2025-01-02T03:04:05.5Z",
+		"updated_at": "2025-02-03T04:05:06Z"
+	}]}`), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != 1 || res.Last != "cursor" {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	collection := res.Collections[0]
+	if collection.Type != aidr.PolicyCollectionTypeGateway || collection.Settings["mode"] != "strict" {
+		t.Errorf("unexpected collection %+v", collection)
+	}
+	if !collection.CreatedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 5e8, time.UTC)) || !collection.UpdatedAt.Equal(time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)) {
+		t.Errorf("unexpected timestamps %s, %s", collection.CreatedAt, collection.UpdatedAt)
+	}
+}
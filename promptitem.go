@@ -0,0 +1,105 @@
+package aidr
+
+import (
+	"slices"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A prompt item, as described by the `aidr-prompt-item` schema. The spec
+// publishes no routes for prompt items, so the SDK has no service for them.
+type PromptItem struct {
+	// Unique id for the item
+	ID string `json:"id"`
+	// Data for the item
+	Content string `json:"content"`
+	// Type for the item
+	Type string `json:"type"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID          respjson.Field
+		Content     respjson.Field
+		Type        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r PromptItem) RawJSON() string { return r.JSON.raw }
+
+func (r *PromptItem) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A list of prompt items, as described by the `aidr-prompt-item-list-result`
+// schema.
+type PromptItemList struct {
+	// The prompt items. The `aidr-prompt-item-list-result` schema names this list
+	// "policies", but its items are `aidr-prompt-item` objects.
+	Items []PromptItem `json:"policies"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Items       respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r PromptItemList) RawJSON() string { return r.JSON.raw }
+
+func (r *PromptItemList) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Get returns the prompt item with the given ID.
+func (r PromptItemList) Get(id string) (PromptItem, bool) {
+	i := slices.IndexFunc(r.Items, func(item PromptItem) bool { return item.ID == id })
+	if i < 0 {
+		return PromptItem{}, false
+	}
+	return r.Items[i], true
+}
+
+// ByType returns the prompt items of the given type, in order.
+func (r PromptItemList) ByType(itemType string) []PromptItem {
+	var res []PromptItem
+	for _, item := range r.Items {
+		if item.Type == itemType {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// Contents returns the content of every prompt item of the given type, in order.
+func (r PromptItemList) Contents(itemType string) []string {
+	var res []string
+	for _, item := range r.ByType(itemType) {
+		res = append(res, item.Content)
+	}
+	return res
+}
+
+// A prompt item definition, as described by the `aidr-prompt-item` schema.
+type PromptItemParams struct {
+	// Unique id for the item
+	ID param.Opt[string] `json:"id,omitzero"`
+	// Data for the item
+	Content param.Opt[string] `json:"content,omitzero"`
+	// Type for the item
+	Type param.Opt[string] `json:"type,omitzero"`
+	paramObj
+}
+
+func (r PromptItemParams) MarshalJSON() (data []byte, err error) {
+	type shadow PromptItemParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *PromptItemParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
@@ -0,0 +1,174 @@
+package aidr
+
+import (
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A saved filter, as described by the `aidr-saved-filter-result` schema. The
+// spec publishes no routes for saved filters, so the SDK has no service for
+// them.
+type SavedFilter struct {
+	// Filter details
+	Filter map[string]any `json:"filter,required"`
+	// Unique name for the saved filter
+	Name string `json:"name,required"`
+	// Timestamp when the record was created (RFC 3339 format)
+	CreatedAt time.Time `json:"created_at,required" format:"date-time"`
+	// Timestamp when the record was last updated (RFC 3339 format)
+	UpdatedAt time.Time `json:"updated_at,required" format:"date-time"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Filter      respjson.Field
+		Name        respjson.Field
+		CreatedAt   respjson.Field
+		UpdatedAt   respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r SavedFilter) RawJSON() string { return r.JSON.raw }
+
+func (r *SavedFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A page of saved filters, as described by the
+// `aidr-saved-filter-search-result` schema.
+type SavedFilterSearchResult struct {
+	// Pagination count of returned records
+	Count int64 `json:"count"`
+	// Pagination last cursor
+	Last         string        `json:"last"`
+	SavedFilters []SavedFilter `json:"saved_filters"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count        respjson.Field
+		Last         respjson.Field
+		SavedFilters respjson.Field
+		ExtraFields  map[string]respjson.Field
+		raw          string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r SavedFilterSearchResult) RawJSON() string { return r.JSON.raw }
+
+func (r *SavedFilterSearchResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// A saved filter definition, as described by the `aidr-saved-filter` schema.
+//
+// The properties Filter, Name are required.
+type SavedFilterParams struct {
+	// Filter details
+	Filter map[string]any `json:"filter,omitzero,required"`
+	// Unique name for the saved filter
+	Name string `json:"name,required"`
+	paramObj
+}
+
+func (r SavedFilterParams) MarshalJSON() (data []byte, err error) {
+	type shadow SavedFilterParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *SavedFilterParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Filters for listing saved filters, as described by the
+// `aidr-saved-filter-search` schema.
+type SavedFilterSearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]              `json:"size,omitzero"`
+	Filter SavedFilterSearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order SavedFilterSearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "name", "created_at", "updated_at".
+	OrderBy SavedFilterSearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r SavedFilterSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow SavedFilterSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *SavedFilterSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type SavedFilterSearchParamsFilter struct {
+	// Only records where created_at equals this value.
+	CreatedAt param.Opt[time.Time] `json:"created_at,omitzero" format:"date-time"`
+	// Only records where created_at is greater than this value.
+	CreatedAtGt param.Opt[time.Time] `json:"created_at__gt,omitzero" format:"date-time"`
+	// Only records where created_at is greater than or equal to this value.
+	CreatedAtGte param.Opt[time.Time] `json:"created_at__gte,omitzero" format:"date-time"`
+	// Only records where created_at is less than this value.
+	CreatedAtLt param.Opt[time.Time] `json:"created_at__lt,omitzero" format:"date-time"`
+	// Only records where created_at is less than or equal to this value.
+	CreatedAtLte param.Opt[time.Time] `json:"created_at__lte,omitzero" format:"date-time"`
+	// Only records where id is equal to the value
+	ID param.Opt[string] `json:"id,omitzero"`
+	// Only records where name is equal to the value
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Only records where updated_at equals this value.
+	UpdatedAt param.Opt[time.Time] `json:"updated_at,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than this value.
+	UpdatedAtGt param.Opt[time.Time] `json:"updated_at__gt,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than or equal to this value.
+	UpdatedAtGte param.Opt[time.Time] `json:"updated_at__gte,omitzero" format:"date-time"`
+	// Only records where updated_at is less than this value.
+	UpdatedAtLt param.Opt[time.Time] `json:"updated_at__lt,omitzero" format:"date-time"`
+	// Only records where updated_at is less than or equal to this value.
+	UpdatedAtLte param.Opt[time.Time] `json:"updated_at__lte,omitzero" format:"date-time"`
+	// Only records where id includes each substring.
+	IDContains []string `json:"id__contains,omitzero"`
+	// Only records where id equals one of the provided substrings.
+	IDIn []string `json:"id__in,omitzero"`
+	// Only records where name includes each substring.
+	NameContains []string `json:"name__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	NameIn []string `json:"name__in,omitzero"`
+	paramObj
+}
+
+func (r SavedFilterSearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow SavedFilterSearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *SavedFilterSearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type SavedFilterSearchParamsOrder string
+
+const (
+	SavedFilterSearchParamsOrderAsc  SavedFilterSearchParamsOrder = "asc"
+	SavedFilterSearchParamsOrderDesc SavedFilterSearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type SavedFilterSearchParamsOrderBy string
+
+const (
+	SavedFilterSearchParamsOrderByName      SavedFilterSearchParamsOrderBy = "name"
+	SavedFilterSearchParamsOrderByCreatedAt SavedFilterSearchParamsOrderBy = "created_at"
+	SavedFilterSearchParamsOrderByUpdatedAt SavedFilterSearchParamsOrderBy = "updated_at"
+)
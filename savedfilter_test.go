@@ -0,0 +1,50 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestSavedFilter(t *testing.T) {
+	body := marshalObject(t, aidr.SavedFilterParams{
+		Name:   "blocked-chat",
+		Filter: map[string]any{"app_id": "chat", "is_blocked": true},
+	})
+	if body["name"] != "blocked-chat" || body["filter"].(map[string]any)["is_blocked"] != true {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var filter aidr.SavedFilter
+	err := json.Unmarshal([]byte(`{
+		"name": "blocked-chat",
+		"filter": {"app_id": "chat", "is_blocked": true},
+		"created_at": "2025-01-01T00:00:00Z",
+		"updated_at": "2025-01-02T00:00:00Z"
+	}`), &filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.Filter["is_blocked"] != true || filter.UpdatedAt.IsZero() {
+		t.Errorf("unexpected saved filter %+v", filter)
+	}
+}
+
+func TestSavedFilterSearch(t *testing.T) {
+	body := marshalObject(t, aidr.SavedFilterSearchParams{
+		Filter:  aidr.SavedFilterSearchParamsFilter{NameContains: []string{"chat"}},
+		OrderBy: aidr.SavedFilterSearchParamsOrderByUpdatedAt,
+	})
+	if body["order_by"] != "updated_at" || body["filter"].(map[string]any)["name__contains"].([]any)[0] != "chat" {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var res aidr.SavedFilterSearchResult
+	if err := json.Unmarshal([]byte(`{"count": 1, "saved_filters": [{"name": "a", "filter": {}}]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.SavedFilters) != 1 || res.SavedFilters[0].Name != "a" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
@@ -0,0 +1,193 @@
+package aidr
+
+import (
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+)
+
+// A page of collector summaries, as described by the
+// `aidr-sensor-insights-result` schema. The spec publishes no routes for
+// sensors, so the SDK has no service for them. See [ClassifySensors] to tell
+// the health of the collectors.
+type SensorInsightsResult struct {
+	// Pagination limit
+	Count int64                `json:"count"`
+	Items []SensorInsightsItem `json:"items"`
+	// Pagination last count
+	Last string `json:"last"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		Count       respjson.Field
+		Items       respjson.Field
+		Last        respjson.Field
+		ExtraFields map[string]respjson.Field
+		raw         string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r SensorInsightsResult) RawJSON() string { return r.JSON.raw }
+
+func (r *SensorInsightsResult) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// The summary of a collector, or of a collector instance, as described by the
+// `aidr-sensor-insights-item` schema.
+type SensorInsightsItem struct {
+	// A service config ID
+	CollectorID string `json:"collector_id,required"`
+	// collector type
+	CollectorType string `json:"collector_type,required"`
+	// total event counts
+	Count int64 `json:"count,required"`
+	// created time
+	CreatedAt time.Time `json:"created_at,required" format:"date-time"`
+	// latest updated time
+	UpdatedAt time.Time `json:"updated_at,required" format:"date-time"`
+	// Collector instance id
+	InstanceID string `json:"instance_id"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		CollectorID   respjson.Field
+		CollectorType respjson.Field
+		Count         respjson.Field
+		CreatedAt     respjson.Field
+		UpdatedAt     respjson.Field
+		InstanceID    respjson.Field
+		ExtraFields   map[string]respjson.Field
+		raw           string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r SensorInsightsItem) RawJSON() string { return r.JSON.raw }
+
+func (r *SensorInsightsItem) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Status classifies the item by comparing the time elapsed since UpdatedAt with
+// the thresholds of config, the service config of its collector. A zero
+// threshold is never reached.
+func (r SensorInsightsItem) Status(config ServiceConfig, now time.Time) SensorStatus {
+	age := now.Sub(r.UpdatedAt)
+	switch {
+	case config.InActiveThreshold > 0 && age >= config.InActiveThreshold:
+		return SensorStatusInactive
+	case config.WarningThreshold > 0 && age >= config.WarningThreshold:
+		return SensorStatusWarning
+	default:
+		return SensorStatusHealthy
+	}
+}
+
+// The health of a collector, derived from the time it last reported.
+type SensorStatus string
+
+const (
+	SensorStatusHealthy  SensorStatus = "healthy"
+	SensorStatusWarning  SensorStatus = "warning"
+	SensorStatusInactive SensorStatus = "inactive"
+	// The service config of the collector is unknown.
+	SensorStatusUnknown SensorStatus = "unknown"
+)
+
+// SensorClassification is the status of one item of [SensorInsightsResult].
+type SensorClassification struct {
+	Item   SensorInsightsItem
+	Status SensorStatus
+	// Age is the time elapsed since the item was last updated.
+	Age time.Duration
+}
+
+// ClassifySensors classifies each item with [SensorInsightsItem.Status], using
+// the service config whose ID is the collector ID of the item. Items whose
+// collector is not in configs are [SensorStatusUnknown].
+func ClassifySensors(items []SensorInsightsItem, configs []ServiceConfig, now time.Time) []SensorClassification {
+	byID := make(map[string]ServiceConfig, len(configs))
+	for _, config := range configs {
+		byID[config.ID] = config
+	}
+	res := make([]SensorClassification, len(items))
+	for i, item := range items {
+		res[i] = SensorClassification{Item: item, Status: SensorStatusUnknown, Age: now.Sub(item.UpdatedAt)}
+		if config, ok := byID[item.CollectorID]; ok {
+			res[i].Status = item.Status(config, now)
+		}
+	}
+	return res
+}
+
+// Filters for listing collector summaries, as described by the
+// `aidr-sensor-insights` schema.
+type SensorInsightsParams struct {
+	// Pagination limit
+	Count param.Opt[int64] `json:"count,omitzero"`
+	// set to get instance level data
+	IsInstanceData param.Opt[bool] `json:"is_instance_data,omitzero"`
+	// Pagination last count
+	Last param.Opt[string] `json:"last,omitzero"`
+	// field to sort by
+	OrderBy param.Opt[string] `json:"order_by,omitzero"`
+	// Optional filters of the form `<field>__contains` or `<field>__in`
+	Filters SensorInsightsParamsFilters `json:"filters,omitzero"`
+	// Sort direction (default: asc)
+	//
+	// Any of "asc", "desc".
+	Order SensorInsightsParamsOrder `json:"order,omitzero"`
+	paramObj
+}
+
+func (r SensorInsightsParams) MarshalJSON() (data []byte, err error) {
+	type shadow SensorInsightsParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *SensorInsightsParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Optional filters of the form `<field>__contains` or `<field>__in`
+type SensorInsightsParamsFilters struct {
+	// Only records where id equals this value.
+	CollectorID param.Opt[string] `json:"collector_id,omitzero"`
+	// Only records where sensor type equals this value.
+	CollectorType param.Opt[string] `json:"collector_type,omitzero"`
+	// Only records where instance id equals this value.
+	InstanceID param.Opt[string] `json:"instance_id,omitzero"`
+	// Only records where id includes each substring.
+	CollectorIDContains []string `json:"collector_id__contains,omitzero"`
+	// Only records where id equals one of the provided substrings.
+	CollectorIDIn []string `json:"collector_id__in,omitzero"`
+	// Only records where sensor type includes each substring. The API spells this
+	// filter with a single underscore.
+	CollectorTypeContains []string `json:"collector_type_contains,omitzero"`
+	// Only records where sensor type equals one of the provided substrings.
+	CollectorTypeIn []string `json:"collector_type__in,omitzero"`
+	// Only records where instance id includes each substring.
+	InstanceIDContains []string `json:"instance_id__contains,omitzero"`
+	// Only records where instance id equals one of the provided substrings.
+	InstanceIDIn []string `json:"instance_id__in,omitzero"`
+	paramObj
+}
+
+func (r SensorInsightsParamsFilters) MarshalJSON() (data []byte, err error) {
+	type shadow SensorInsightsParamsFilters
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *SensorInsightsParamsFilters) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Sort direction (default: asc)
+type SensorInsightsParamsOrder string
+
+const (
+	SensorInsightsParamsOrderAsc  SensorInsightsParamsOrder = "asc"
+	SensorInsightsParamsOrderDesc SensorInsightsParamsOrder = "desc"
+)
@@ -0,0 +1,76 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestSensorInsights(t *testing.T) {
+	body := marshalObject(t, aidr.SensorInsightsParams{
+		IsInstanceData: aidr.Bool(true),
+		Filters: aidr.SensorInsightsParamsFilters{
+			CollectorTypeContains: []string{"gate"},
+			InstanceIDIn:          []string{"i-1", "i-2"},
+		},
+	})
+	filters := body["filters"].(map[string]any)
+	if body["is_instance_data"] != true {
+		t.Errorf("unexpected body %v", body)
+	}
+	if filters["collector_type_contains"].([]any)[0] != "gate" || len(filters["instance_id__in"].([]any)) != 2 {
+		t.Errorf("unexpected filters %v", filters)
+	}
+
+	var res aidr.SensorInsightsResult
+	err := json.Unmarshal([]byte(`{"count": 2, "last": "2", "items": [
+		{"collector_id": "pci_a", "collector_type": "gateway", "instance_id": "i-1", "count": 10, "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-03-01T11:59:00Z"},
+		{"collector_id": "pci_a", "collector_type": "gateway", "instance_id": "i-2", "count": 5, "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-03-01T11:50:00Z"}
+	]}`), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 2 || res.Items[0].InstanceID != "i-1" || res.Items[1].Count != 5 || res.Last != "2" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestClassifySensors(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	item := func(collector string, age time.Duration) aidr.SensorInsightsItem {
+		return aidr.SensorInsightsItem{CollectorID: collector, UpdatedAt: now.Add(-age)}
+	}
+	var configs []aidr.ServiceConfig
+	err := json.Unmarshal([]byte(`[
+		{"id": "pci_a", "name": "a", "warning_threshold": "5m", "in_active_threshold": "1h"},
+		{"id": "pci_b", "name": "b", "warning_threshold": "5m", "in_active_threshold": ""}
+	]`), &configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	classes := aidr.ClassifySensors([]aidr.SensorInsightsItem{
+		item("pci_a", time.Minute),
+		item("pci_a", 5*time.Minute),
+		item("pci_a", 2*time.Hour),
+		item("pci_b", 48*time.Hour),
+		item("pci_c", time.Minute),
+	}, configs, now)
+
+	want := []aidr.SensorStatus{
+		aidr.SensorStatusHealthy,
+		aidr.SensorStatusWarning,
+		aidr.SensorStatusInactive,
+		aidr.SensorStatusWarning,
+		aidr.SensorStatusUnknown,
+	}
+	for i, c := range classes {
+		if c.Status != want[i] {
+			t.Errorf("item %d: got %s, want %s", i, c.Status, want[i])
+		}
+	}
+	if classes[2].Age != 2*time.Hour {
+		t.Errorf("unexpected age %s", classes[2].Age)
+	}
+}
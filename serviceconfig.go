@@ -0,0 +1,311 @@
+package aidr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/crowdstrike/aidr-go/internal/apijson"
+	"github.com/crowdstrike/aidr-go/packages/param"
+	"github.com/crowdstrike/aidr-go/packages/respjson"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// A collector service config, as described by the `aidr-service-config-result`
+// schema. The spec publishes no routes for service configs, so the SDK has no
+// service for them.
+//
+// The thresholds are encoded as duration strings such as "90s" or "2h", as
+// described by the `aidr-golang-duration` schema.
+type ServiceConfig struct {
+	// A service config ID
+	ID   string `json:"id,required"`
+	Name string `json:"name,required"`
+	// Type of the collector.
+	CollectorType string `json:"collector_type"`
+	// Duration after which a collector that has not reported is inactive.
+	InActiveThreshold time.Duration `json:"in_active_threshold"`
+	// A metric pool ID
+	MetricPoolRid string `json:"metric_pool_rid"`
+	// Collector type specific settings.
+	Settings map[string]any `json:"settings"`
+	// Timestamp when the record was last updated (RFC 3339 format)
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+	Version   string    `json:"version"`
+	// Duration after which a collector that has not reported raises a warning.
+	WarningThreshold time.Duration `json:"warning_threshold"`
+	// JSON contains metadata for fields, check presence with [respjson.Field.Valid].
+	JSON struct {
+		ID                respjson.Field
+		Name              respjson.Field
+		CollectorType     respjson.Field
+		InActiveThreshold respjson.Field
+		MetricPoolRid     respjson.Field
+		Settings          respjson.Field
+		UpdatedAt         respjson.Field
+		Version           respjson.Field
+		WarningThreshold  respjson.Field
+		ExtraFields       map[string]respjson.Field
+		raw               string
+	} `json:"-"`
+}
+
+// Returns the unmodified JSON received from the API
+func (r ServiceConfig) RawJSON() string { return r.JSON.raw }
+
+func (r *ServiceConfig) UnmarshalJSON(data []byte) error {
+	if err := apijson.UnmarshalRoot(data, r); err != nil {
+		return err
+	}
+	thresholds := []struct {
+		key   string
+		value *time.Duration
+		field *respjson.Field
+	}{
+		{"in_active_threshold", &r.InActiveThreshold, &r.JSON.InActiveThreshold},
+		{"warning_threshold", &r.WarningThreshold, &r.JSON.WarningThreshold},
+	}
+	for _, t := range thresholds {
+		res := gjson.GetBytes(data, t.key)
+		if res.Type != gjson.String {
+			continue
+		}
+		d, err := parseDuration(res.Str)
+		if err != nil {
+			return err
+		}
+		*t.value, *t.field = d, respjson.NewField(res.Raw)
+	}
+	return nil
+}
+
+// A collector service config definition, as described by the
+// `aidr-service-config` schema.
+type ServiceConfigParams struct {
+	// A service config ID
+	ID            param.Opt[string] `json:"id,omitzero"`
+	Name          param.Opt[string] `json:"name,omitzero"`
+	CollectorType param.Opt[string] `json:"collector_type,omitzero"`
+	// Duration after which a collector that has not reported is inactive.
+	InActiveThreshold param.Opt[time.Duration] `json:"in_active_threshold,omitzero"`
+	// A metric pool ID
+	MetricPoolRid param.Opt[string] `json:"metric_pool_rid,omitzero"`
+	Version       param.Opt[string] `json:"version,omitzero"`
+	// Duration after which a collector that has not reported raises a warning.
+	WarningThreshold param.Opt[time.Duration] `json:"warning_threshold,omitzero"`
+	// Collector type specific settings.
+	Settings map[string]any `json:"settings,omitzero"`
+	paramObj
+}
+
+func (r ServiceConfigParams) MarshalJSON() (data []byte, err error) {
+	type shadow ServiceConfigParams
+	data, err = param.MarshalObject(r, (*shadow)(&r))
+	if _, ok := r.Overrides(); err != nil || ok {
+		return data, err
+	}
+	thresholds := []struct {
+		key   string
+		value param.Opt[time.Duration]
+	}{
+		{"in_active_threshold", r.InActiveThreshold},
+		{"warning_threshold", r.WarningThreshold},
+	}
+	for _, t := range thresholds {
+		if !t.value.Valid() {
+			continue
+		}
+		s, err := formatDuration(t.value.Value)
+		if err != nil {
+			return nil, err
+		}
+		data, err = sjson.SetBytes(data, t.key, s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func (r *ServiceConfigParams) UnmarshalJSON(data []byte) (err error) {
+	thresholds := []struct {
+		key   string
+		value *param.Opt[time.Duration]
+	}{
+		{"in_active_threshold", &r.InActiveThreshold},
+		{"warning_threshold", &r.WarningThreshold},
+	}
+	parsed := make([]param.Opt[time.Duration], len(thresholds))
+	for i, t := range thresholds {
+		res := gjson.GetBytes(data, t.key)
+		switch res.Type {
+		case gjson.String:
+			d, err := parseDuration(res.Str)
+			if err != nil {
+				return err
+			}
+			parsed[i] = param.NewOpt(d)
+		case gjson.Null:
+			if res.Exists() {
+				parsed[i] = param.Null[time.Duration]()
+			}
+		default:
+			return fmt.Errorf("aidr: %s must be a duration string", t.key)
+		}
+		if data, err = sjson.DeleteBytes(data, t.key); err != nil {
+			return err
+		}
+	}
+	if err := apijson.UnmarshalRoot(data, r); err != nil {
+		return err
+	}
+	for i, t := range thresholds {
+		*t.value = parsed[i]
+	}
+	return nil
+}
+
+// Filters for listing collector service configs, as described by the
+// `aidr-service-config-list` schema.
+type ServiceConfigSearchParams struct {
+	// Reflected value from a previous response to obtain the next page of results.
+	Last param.Opt[string] `json:"last,omitzero"`
+	// Maximum results to include in the response.
+	Size   param.Opt[int64]                `json:"size,omitzero"`
+	Filter ServiceConfigSearchParamsFilter `json:"filter,omitzero"`
+	// Order results asc(ending) or desc(ending).
+	//
+	// Any of "asc", "desc".
+	Order ServiceConfigSearchParamsOrder `json:"order,omitzero"`
+	// Which field to order results by.
+	//
+	// Any of "id", "created_at", "updated_at".
+	OrderBy ServiceConfigSearchParamsOrderBy `json:"order_by,omitzero"`
+	paramObj
+}
+
+func (r ServiceConfigSearchParams) MarshalJSON() (data []byte, err error) {
+	type shadow ServiceConfigSearchParams
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ServiceConfigSearchParams) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+type ServiceConfigSearchParamsFilter struct {
+	// Only records where collector_type equals this value.
+	CollectorType param.Opt[string] `json:"collector_type,omitzero"`
+	// Only records where created_at equals this value.
+	CreatedAt param.Opt[time.Time] `json:"created_at,omitzero" format:"date-time"`
+	// Only records where created_at is greater than this value.
+	CreatedAtGt param.Opt[time.Time] `json:"created_at__gt,omitzero" format:"date-time"`
+	// Only records where created_at is greater than or equal to this value.
+	CreatedAtGte param.Opt[time.Time] `json:"created_at__gte,omitzero" format:"date-time"`
+	// Only records where created_at is less than this value.
+	CreatedAtLt param.Opt[time.Time] `json:"created_at__lt,omitzero" format:"date-time"`
+	// Only records where created_at is less than or equal to this value.
+	CreatedAtLte param.Opt[time.Time] `json:"created_at__lte,omitzero" format:"date-time"`
+	// Only records where id equals this value.
+	ID param.Opt[string] `json:"id,omitzero"`
+	// Only records where name equals this value.
+	Name param.Opt[string] `json:"name,omitzero"`
+	// Only records where updated_at equals this value.
+	UpdatedAt param.Opt[time.Time] `json:"updated_at,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than this value.
+	UpdatedAtGt param.Opt[time.Time] `json:"updated_at__gt,omitzero" format:"date-time"`
+	// Only records where updated_at is greater than or equal to this value.
+	UpdatedAtGte param.Opt[time.Time] `json:"updated_at__gte,omitzero" format:"date-time"`
+	// Only records where updated_at is less than this value.
+	UpdatedAtLt param.Opt[time.Time] `json:"updated_at__lt,omitzero" format:"date-time"`
+	// Only records where updated_at is less than or equal to this value.
+	UpdatedAtLte param.Opt[time.Time] `json:"updated_at__lte,omitzero" format:"date-time"`
+	// Only records where collector_type includes each substring.
+	CollectorTypeContains []string `json:"collector_type__contains,omitzero"`
+	// Only records where collector_type equals one of the provided substrings.
+	CollectorTypeIn []string `json:"collector_type__in,omitzero"`
+	// Only records where id includes each substring.
+	IDContains []string `json:"id__contains,omitzero"`
+	// Only records where id equals one of the provided substrings.
+	IDIn []string `json:"id__in,omitzero"`
+	// Only records where name includes each substring.
+	NameContains []string `json:"name__contains,omitzero"`
+	// Only records where name equals one of the provided substrings.
+	NameIn []string `json:"name__in,omitzero"`
+	paramObj
+}
+
+func (r ServiceConfigSearchParamsFilter) MarshalJSON() (data []byte, err error) {
+	type shadow ServiceConfigSearchParamsFilter
+	return param.MarshalObject(r, (*shadow)(&r))
+}
+
+func (r *ServiceConfigSearchParamsFilter) UnmarshalJSON(data []byte) error {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+// Order results asc(ending) or desc(ending).
+type ServiceConfigSearchParamsOrder string
+
+const (
+	ServiceConfigSearchParamsOrderAsc  ServiceConfigSearchParamsOrder = "asc"
+	ServiceConfigSearchParamsOrderDesc ServiceConfigSearchParamsOrder = "desc"
+)
+
+// Which field to order results by.
+type ServiceConfigSearchParamsOrderBy string
+
+const (
+	ServiceConfigSearchParamsOrderByID        ServiceConfigSearchParamsOrderBy = "id"
+	ServiceConfigSearchParamsOrderByCreatedAt ServiceConfigSearchParamsOrderBy = "created_at"
+	ServiceConfigSearchParamsOrderByUpdatedAt ServiceConfigSearchParamsOrderBy = "updated_at"
+)
+
+// The pattern of a duration in the `aidr-golang-duration` schema.
+var durationPattern = regexp.MustCompile(`^[0-9]+(ns|us|µs|ms|s|m|h)$`)
+
+var durationUnits = []struct {
+	unit   time.Duration
+	suffix string
+}{
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "us"},
+}
+
+// parseDuration parses a duration made of an integer and a single unit, such as
+// "100ms" or "2h". The empty string is zero.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if !durationPattern.MatchString(s) {
+		return 0, fmt.Errorf("aidr: invalid duration %q, expected an integer and one of the units ns, us, µs, ms, s, m, h", s)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("aidr: invalid duration %q: %w", s, err)
+	}
+	return d, nil
+}
+
+// formatDuration formats d with the largest unit that divides it exactly, such
+// as "90s". Unlike [time.Duration.String], it never mixes several units.
+func formatDuration(d time.Duration) (string, error) {
+	if d < 0 {
+		return "", fmt.Errorf("aidr: cannot encode negative duration %s", d)
+	}
+	if d == 0 {
+		return "0s", nil
+	}
+	for _, u := range durationUnits {
+		if d%u.unit == 0 {
+			return strconv.FormatInt(int64(d/u.unit), 10) + u.suffix, nil
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns", nil
+}
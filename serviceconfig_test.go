@@ -0,0 +1,102 @@
+package aidr_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func TestServiceConfigThresholds(t *testing.T) {
+	body := marshalObject(t, aidr.ServiceConfigParams{
+		Name:              aidr.String("tenant-a"),
+		CollectorType:     aidr.String("gateway"),
+		WarningThreshold:  aidr.Opt(90 * time.Second),
+		InActiveThreshold: aidr.Opt(2 * time.Hour),
+		Settings:          map[string]any{"region": "eu"},
+	})
+	if body["warning_threshold"] != "90s" || body["in_active_threshold"] != "2h" || body["name"] != "tenant-a" {
+		t.Errorf("unexpected body %v", body)
+	}
+
+	var config aidr.ServiceConfig
+	err := json.Unmarshal([]byte(`{
+		"id": "pci_xpkhwpnz2cmegsws737xbsqnmnuwtbm5",
+		"name": "tenant-a",
+		"collector_type": "gateway",
+		"metric_pool_rid": "pro_xpkhwpnz2cmegsws737xbsqnmnuwtbm5",
+		"warning_threshold": "1500ms",
+		"in_active_threshold": "2h"
+	}`), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.WarningThreshold != 1500*time.Millisecond || config.InActiveThreshold != 2*time.Hour {
+		t.Errorf("unexpected thresholds %s, %s", config.WarningThreshold, config.InActiveThreshold)
+	}
+	if !config.JSON.WarningThreshold.Valid() || config.JSON.WarningThreshold.Raw() != `"1500ms"` {
+		t.Errorf("unexpected warning threshold metadata %+v", config.JSON.WarningThreshold)
+	}
+	if config.MetricPoolRid != "pro_xpkhwpnz2cmegsws737xbsqnmnuwtbm5" || config.RawJSON() == "" {
+		t.Errorf("unexpected config %+v", config)
+	}
+}
+
+func TestServiceConfigDurationRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, time.Nanosecond, 1500 * time.Microsecond, 250 * time.Millisecond, 90 * time.Second, 45 * time.Minute, 36 * time.Hour} {
+		data, err := json.Marshal(aidr.ServiceConfigParams{WarningThreshold: aidr.Opt(d)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var params aidr.ServiceConfigParams
+		if err := json.Unmarshal(data, &params); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+		if params.WarningThreshold.Value != d || params.InActiveThreshold.Valid() {
+			t.Errorf("%s decoded as %+v, want %s", data, params, d)
+		}
+	}
+
+	if _, err := json.Marshal(aidr.ServiceConfigParams{WarningThreshold: aidr.Opt(-time.Second)}); err == nil {
+		t.Error("expected an error for a negative duration")
+	}
+
+	var config aidr.ServiceConfig
+	if err := json.Unmarshal([]byte(`{"id": "pci_x", "name": "x", "warning_threshold": "", "in_active_threshold": "5µs"}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.WarningThreshold != 0 || config.InActiveThreshold != 5*time.Microsecond {
+		t.Errorf("unexpected thresholds %s, %s", config.WarningThreshold, config.InActiveThreshold)
+	}
+
+	for _, invalid := range []string{`"1h30m"`, `"1.5s"`, `"-1s"`, `"90"`} {
+		if err := json.Unmarshal([]byte(`{"id": "pci_x", "name": "x", "warning_threshold": `+invalid+`}`), &config); err == nil {
+			t.Errorf("expected an error for %s, got %s", invalid, config.WarningThreshold)
+		}
+		var params aidr.ServiceConfigParams
+		if err := json.Unmarshal([]byte(`{"in_active_threshold": `+invalid+`}`), &params); err == nil {
+			t.Errorf("expected an error for %s, got %+v", invalid, params.InActiveThreshold)
+		}
+	}
+}
+
+func TestServiceConfigDurationIsNotGlobal(t *testing.T) {
+	data, err := json.Marshal(aidr.ServiceConfigParams{Settings: map[string]any{"timeout": 90 * time.Second}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"settings":{"timeout":90000000000}}` {
+		t.Errorf("expected time.Duration to keep its default encoding, got %s", data)
+	}
+}
+
+func TestServiceConfigSearchParams(t *testing.T) {
+	body := marshalObject(t, aidr.ServiceConfigSearchParams{
+		Filter:  aidr.ServiceConfigSearchParamsFilter{CollectorTypeIn: []string{"gateway"}},
+		OrderBy: aidr.ServiceConfigSearchParamsOrderByUpdatedAt,
+	})
+	if body["filter"].(map[string]any)["collector_type__in"].([]any)[0] != "gateway" || body["order_by"] != "updated_at" {
+		t.Errorf("unexpected body %v", body)
+	}
+}
@@ -0,0 +1,235 @@
+package aidr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/crowdstrike/aidr-go/option"
+)
+
+// ToolFunc is a tool that can be called by a model. It receives the arguments of
+// the call, as JSON generated by the model, and returns the result passed back to
+// the model.
+type ToolFunc func(ctx context.Context, arguments string) (string, error)
+
+// GuardedTool is a [ToolFunc] wrapped by [AIGuardService.GuardTool]. It is called
+// with the tool call requested by the model.
+type GuardedTool func(ctx context.Context, call ChatToolCallParam) (string, error)
+
+// ToolBlockedError is returned by a [GuardedTool] when the API blocks the
+// arguments or the result of a tool call. Use [ToolBlockedError.ToolMessage] to
+// tell the model that the call was blocked.
+type ToolBlockedError struct {
+	// EventType is "tool_input" when the call was blocked before the tool ran, and
+	// "tool_output" when its result was blocked.
+	EventType  AIGuardGuardChatCompletionsParamsEventType
+	ToolName   string
+	ToolCallID string
+	// Result is the result of the guard request that blocked the call.
+	Result AIGuardGuardChatCompletionsResponseResult
+}
+
+func (e *ToolBlockedError) Error() string {
+	if e.EventType == AIGuardGuardChatCompletionsParamsEventTypeToolListing {
+		return fmt.Sprintf("aidr: tool listing blocked by policy %q", e.Result.Policy)
+	}
+	return fmt.Sprintf("aidr: tool call %s (%s) blocked on %s by policy %q", e.ToolName, e.ToolCallID, e.EventType, e.Result.Policy)
+}
+
+// ToolMessage returns a "tool" message answering the blocked call, to be sent to
+// the model in place of the result of the tool.
+func (e *ToolBlockedError) ToolMessage() ChatMessageParam {
+	content := fmt.Sprintf("The call to the tool %s was blocked by policy.", e.ToolName)
+	if e.EventType == AIGuardGuardChatCompletionsParamsEventTypeToolOutput {
+		content = fmt.Sprintf("The result of the tool %s was blocked by policy.", e.ToolName)
+	}
+	return ToolMessage(content, e.ToolCallID)
+}
+
+// GuardTool wraps fn so that the arguments of each call are guarded as a
+// "tool_input" event before fn runs, and its result as a "tool_output" event
+// after. The requests are sent with the given metadata. When the API transforms
+// the arguments or the result, for instance to redact them, the transformed
+// values are used.
+//
+// A blocked call returns a [*ToolBlockedError]; fn does not run when the
+// arguments are blocked. Errors of fn are returned as is.
+func (r *AIGuardService) GuardTool(metadata AIGuardGuardChatCompletionsParams, fn ToolFunc, opts ...option.RequestOption) GuardedTool {
+	return func(ctx context.Context, call ChatToolCallParam) (string, error) {
+		if call.Type == "" {
+			call.Type = "function"
+		}
+		callMsg := ChatMessageParam{Role: ChatMessageRoleAssistant, ToolCalls: []ChatToolCallParam{call}}
+
+		input := metadata
+		input.EventType = AIGuardGuardChatCompletionsParamsEventTypeToolInput
+		input.GuardInput = AIGuardGuardChatCompletionsParamsGuardInput{Messages: []ChatMessageParam{callMsg}}
+		res, err := r.GuardChatCompletions(ctx, input, opts...)
+		if err != nil {
+			return "", err
+		}
+		if res.Result.Blocked {
+			return "", &ToolBlockedError{EventType: input.EventType, ToolName: call.Function.Name, ToolCallID: call.ID, Result: res.Result}
+		}
+		if args, ok := transformedToolArguments(res.Result, call.ID); ok {
+			call.Function.Arguments = args
+			callMsg.ToolCalls = []ChatToolCallParam{call}
+		}
+
+		out, err := fn(ctx, call.Function.Arguments)
+		if err != nil {
+			return "", err
+		}
+
+		output := metadata
+		output.EventType = AIGuardGuardChatCompletionsParamsEventTypeToolOutput
+		output.GuardInput = AIGuardGuardChatCompletionsParamsGuardInput{Messages: []ChatMessageParam{callMsg, ToolMessage(out, call.ID)}}
+		res, err = r.GuardChatCompletions(ctx, output, opts...)
+		if err != nil {
+			return "", err
+		}
+		if res.Result.Blocked {
+			return "", &ToolBlockedError{EventType: output.EventType, ToolName: call.Function.Name, ToolCallID: call.ID, Result: res.Result}
+		}
+		if content, ok := transformedToolResult(res.Result, call.ID); ok {
+			out = content
+		}
+		return out, nil
+	}
+}
+
+func transformedToolArguments(result AIGuardGuardChatCompletionsResponseResult, callID string) (string, bool) {
+	if !result.Transformed {
+		return "", false
+	}
+	for _, msg := range result.GuardOutput.Messages {
+		for _, call := range msg.ToolCalls {
+			if call.ID == callID {
+				return call.Function.Arguments, true
+			}
+		}
+	}
+	return "", false
+}
+
+func transformedToolResult(result AIGuardGuardChatCompletionsResponseResult, callID string) (string, bool) {
+	if !result.Transformed {
+		return "", false
+	}
+	for _, msg := range result.GuardOutput.Messages {
+		if msg.Role == ChatMessageRoleTool && msg.ToolCallID == callID && msg.Content.JSON.OfString.Valid() {
+			return msg.Content.AsString(), true
+		}
+	}
+	return "", false
+}
+
+// ToolRegistry holds the tools available to an agent, grouped by MCP server. It
+// guards their calls with [AIGuardService.GuardTool] and their listing as a
+// "tool_listing" event. Create one with [AIGuardService.NewToolRegistry].
+type ToolRegistry struct {
+	// Metadata is sent with every request of the registry.
+	Metadata AIGuardGuardChatCompletionsParams
+
+	service *AIGuardService
+	opts    []option.RequestOption
+	servers []string
+	tools   map[registeredTool]ToolFunc
+}
+
+type registeredTool struct {
+	server string
+	name   string
+}
+
+// NewToolRegistry returns an empty [ToolRegistry] whose requests are sent with the
+// given metadata and options.
+func (r *AIGuardService) NewToolRegistry(metadata AIGuardGuardChatCompletionsParams, opts ...option.RequestOption) *ToolRegistry {
+	return &ToolRegistry{Metadata: metadata, service: r, opts: opts, tools: map[registeredTool]ToolFunc{}}
+}
+
+// Register adds the tool name of the MCP server to the registry. Tools are keyed
+// by server and name, so several servers can provide a tool of the same name.
+// Registering a tool of a server again replaces it.
+func (t *ToolRegistry) Register(server, name string, fn ToolFunc) {
+	if !slices.Contains(t.servers, server) {
+		t.servers = append(t.servers, server)
+	}
+	t.tools[registeredTool{server: server, name: name}] = fn
+}
+
+// McpTools returns the registered tools grouped by server, with the servers in
+// the order they were first registered and the tools of each server sorted by
+// name.
+func (t *ToolRegistry) McpTools() []AIGuardGuardChatCompletionsParamsExtraInfoMcpTool {
+	var res []AIGuardGuardChatCompletionsParamsExtraInfoMcpTool
+	for _, server := range t.servers {
+		var names []string
+		for tool := range t.tools {
+			if tool.server == server {
+				names = append(names, tool.name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		slices.Sort(names)
+		res = append(res, AIGuardGuardChatCompletionsParamsExtraInfoMcpTool{ServerName: server, Tools: names})
+	}
+	return res
+}
+
+// GuardListing guards the registered tools as a "tool_listing" event, with
+// ExtraInfo.McpTools built from the registry. If the API blocks the listing, the
+// returned error is a [*ToolBlockedError] without a tool name.
+func (t *ToolRegistry) GuardListing(ctx context.Context, opts ...option.RequestOption) (*AIGuardGuardChatCompletionsResponse, error) {
+	body := t.Metadata
+	body.EventType = AIGuardGuardChatCompletionsParamsEventTypeToolListing
+	body.ExtraInfo.McpTools = t.McpTools()
+	body.GuardInput = AIGuardGuardChatCompletionsParamsGuardInput{Messages: []ChatMessageParam{}}
+	res, err := t.service.GuardChatCompletions(ctx, body, slices.Concat(t.opts, opts)...)
+	if err != nil {
+		return res, err
+	}
+	if res.Result.Blocked {
+		return res, &ToolBlockedError{EventType: body.EventType, Result: res.Result}
+	}
+	return res, nil
+}
+
+// Call runs the registered tool requested by call, guarding its arguments and
+// result with ExtraInfo.McpTools set to the tool and its server. See
+// [AIGuardService.GuardTool].
+//
+// Call returns an error when several servers provide a tool of that name; use
+// [ToolRegistry.CallServer] to pick the server.
+func (t *ToolRegistry) Call(ctx context.Context, call ChatToolCallParam, opts ...option.RequestOption) (string, error) {
+	var servers []string
+	for _, server := range t.servers {
+		if _, ok := t.tools[registeredTool{server: server, name: call.Function.Name}]; ok {
+			servers = append(servers, server)
+		}
+	}
+	switch len(servers) {
+	case 0:
+		return "", errors.New("aidr: unknown tool " + call.Function.Name)
+	case 1:
+		return t.CallServer(ctx, servers[0], call, opts...)
+	default:
+		return "", fmt.Errorf("aidr: tool %s is provided by several servers %q", call.Function.Name, servers)
+	}
+}
+
+// CallServer is like [ToolRegistry.Call], but runs the tool of the given MCP
+// server.
+func (t *ToolRegistry) CallServer(ctx context.Context, server string, call ChatToolCallParam, opts ...option.RequestOption) (string, error) {
+	fn, ok := t.tools[registeredTool{server: server, name: call.Function.Name}]
+	if !ok {
+		return "", fmt.Errorf("aidr: unknown tool %s of server %s", call.Function.Name, server)
+	}
+	metadata := t.Metadata
+	metadata.ExtraInfo.McpTools = []AIGuardGuardChatCompletionsParamsExtraInfoMcpTool{{ServerName: server, Tools: []string{call.Function.Name}}}
+	return t.service.GuardTool(metadata, fn, slices.Concat(t.opts, opts)...)(ctx, call)
+}
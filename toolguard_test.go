@@ -0,0 +1,158 @@
+package aidr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crowdstrike/aidr-go"
+)
+
+func weatherCall(args string) aidr.ChatToolCallParam {
+	return aidr.ChatToolCallParam{
+		ID:       "call_1",
+		Function: aidr.ChatToolCallFunctionParam{Name: "get_weather", Arguments: args},
+	}
+}
+
+func TestGuardTool(t *testing.T) {
+	client, bodies := sequenceClient(t,
+		guardResponse("prq_1", `{"detectors": {}, "transformed": true, "guard_output": {"messages": [
+			{"role": "assistant", "tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"<CITY>\"}"}}]}
+		]}}`),
+		guardResponse("prq_2", `{"detectors": {}, "transformed": true, "guard_output": {"messages": [
+			{"role": "assistant", "tool_calls": []},
+			{"role": "tool", "tool_call_id": "call_1", "content": "sunny at <ADDRESS>"}
+		]}}`),
+	)
+	var gotArgs string
+	tool := client.AIGuard.GuardTool(aidr.AIGuardGuardChatCompletionsParams{AppID: aidr.String("agent")}, func(ctx context.Context, args string) (string, error) {
+		gotArgs = args
+		return "sunny at 1 Main St", nil
+	})
+
+	out, err := tool(context.Background(), weatherCall(`{"city":"Paris"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotArgs != `{"city":"<CITY>"}` || out != "sunny at <ADDRESS>" {
+		t.Errorf("expected the transformed values, got arguments %s and result %s", gotArgs, out)
+	}
+
+	events := []string{"tool_input", "tool_output"}
+	for i, body := range *bodies {
+		if body["event_type"] != events[i] || body["app_id"] != "agent" {
+			t.Errorf("request %d: unexpected body %v", i, body)
+		}
+	}
+	msgs := (*bodies)[1]["guard_input"].(map[string]any)["messages"].([]any)
+	if len(msgs) != 2 || msgs[1].(map[string]any)["tool_call_id"] != "call_1" || msgs[1].(map[string]any)["content"] != "sunny at 1 Main St" {
+		t.Errorf("unexpected tool_output messages %v", msgs)
+	}
+}
+
+func TestGuardToolBlocked(t *testing.T) {
+	client, bodies := sequenceClient(t, guardResponse("prq_1", `{"detectors": {}, "blocked": true, "policy": "agents"}`))
+	ran := false
+	tool := client.AIGuard.GuardTool(aidr.AIGuardGuardChatCompletionsParams{}, func(ctx context.Context, args string) (string, error) {
+		ran = true
+		return "", nil
+	})
+
+	_, err := tool(context.Background(), weatherCall(`{"city":"Paris"}`))
+	var blocked *aidr.ToolBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("expected a ToolBlockedError, got %v", err)
+	}
+	if ran || len(*bodies) != 1 {
+		t.Error("expected the tool not to run")
+	}
+	if blocked.EventType != aidr.AIGuardGuardChatCompletionsParamsEventTypeToolInput || blocked.ToolName != "get_weather" || blocked.Result.Policy != "agents" {
+		t.Errorf("unexpected error %+v", blocked)
+	}
+	if msg := blocked.ToolMessage(); msg.Role != aidr.ChatMessageRoleTool || msg.ToolCallID.Value != "call_1" {
+		t.Errorf("unexpected tool message %+v", msg)
+	}
+}
+
+func TestToolRegistry(t *testing.T) {
+	client, bodies := sequenceClient(t,
+		guardResponse("prq_1", `{"detectors": {}}`),
+		guardResponse("prq_2", `{"detectors": {}}`),
+		guardResponse("prq_3", `{"detectors": {}, "blocked": true}`),
+	)
+	registry := client.AIGuard.NewToolRegistry(aidr.AIGuardGuardChatCompletionsParams{TenantID: aidr.String("t_1")})
+	echo := func(ctx context.Context, args string) (string, error) { return args, nil }
+	registry.Register("weather", "get_weather", echo)
+	registry.Register("files", "read_file", echo)
+	registry.Register("weather", "get_forecast", echo)
+
+	if _, err := registry.GuardListing(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	listing := (*bodies)[0]
+	if listing["event_type"] != "tool_listing" || listing["tenant_id"] != "t_1" {
+		t.Errorf("unexpected listing body %v", listing)
+	}
+	tools := listing["extra_info"].(map[string]any)["mcp_tools"].([]any)
+	if len(tools) != 2 {
+		t.Fatalf("unexpected mcp tools %v", tools)
+	}
+	weather := tools[0].(map[string]any)
+	if weather["server_name"] != "weather" || len(weather["tools"].([]any)) != 2 || weather["tools"].([]any)[0] != "get_forecast" {
+		t.Errorf("unexpected weather tools %v", weather)
+	}
+
+	_, err := registry.Call(context.Background(), weatherCall(`{}`))
+	var blocked *aidr.ToolBlockedError
+	if !errors.As(err, &blocked) || blocked.EventType != aidr.AIGuardGuardChatCompletionsParamsEventTypeToolOutput {
+		t.Fatalf("expected the tool output to be blocked, got %v", err)
+	}
+	input := (*bodies)[1]["extra_info"].(map[string]any)["mcp_tools"].([]any)[0].(map[string]any)
+	if input["server_name"] != "weather" || input["tools"].([]any)[0] != "get_weather" {
+		t.Errorf("unexpected tool_input mcp tools %v", input)
+	}
+
+	if _, err := registry.Call(context.Background(), aidr.ChatToolCallParam{Function: aidr.ChatToolCallFunctionParam{Name: "nope"}}); err == nil {
+		t.Error("expected an error for an unknown tool")
+	}
+}
+
+func TestToolRegistrySharedToolName(t *testing.T) {
+	client, bodies := sequenceClient(t,
+		guardResponse("prq_1", `{"detectors": {}}`),
+		guardResponse("prq_2", `{"detectors": {}}`),
+		guardResponse("prq_3", `{"detectors": {}}`),
+	)
+	registry := client.AIGuard.NewToolRegistry(aidr.AIGuardGuardChatCompletionsParams{})
+	registry.Register("local", "read_file", func(ctx context.Context, args string) (string, error) { return "local", nil })
+	registry.Register("remote", "read_file", func(ctx context.Context, args string) (string, error) { return "remote", nil })
+
+	if _, err := registry.GuardListing(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	tools := (*bodies)[0]["extra_info"].(map[string]any)["mcp_tools"].([]any)
+	if len(tools) != 2 || tools[0].(map[string]any)["server_name"] != "local" || tools[1].(map[string]any)["server_name"] != "remote" {
+		t.Errorf("expected both servers to list read_file, got %v", tools)
+	}
+
+	call := aidr.ChatToolCallParam{ID: "call_1", Function: aidr.ChatToolCallFunctionParam{Name: "read_file", Arguments: `{}`}}
+	if _, err := registry.Call(context.Background(), call); err == nil {
+		t.Error("expected an error for an ambiguous tool name")
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("expected no request for an ambiguous tool name, got %d requests", len(*bodies))
+	}
+
+	out, err := registry.CallServer(context.Background(), "remote", call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "remote" {
+		t.Errorf("expected the tool of the remote server to run, got %q", out)
+	}
+	input := (*bodies)[1]["extra_info"].(map[string]any)["mcp_tools"].([]any)[0].(map[string]any)
+	if input["server_name"] != "remote" {
+		t.Errorf("unexpected tool_input mcp tools %v", input)
+	}
+}